@@ -0,0 +1,113 @@
+package delugeclient
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AuthLevel is what a daemon account is allowed to do
+type AuthLevel int
+
+const (
+	AuthNone     AuthLevel = 0
+	AuthReadOnly AuthLevel = 1
+	AuthNormal   AuthLevel = 5
+	AuthAdmin    AuthLevel = 10
+)
+
+func (l AuthLevel) String() string {
+	switch l {
+	case AuthNone:
+		return "NONE"
+	case AuthReadOnly:
+		return "READONLY"
+	case AuthNormal:
+		return "NORMAL"
+	case AuthAdmin:
+		return "ADMIN"
+	}
+	return strconv.Itoa(int(l))
+}
+
+// Account is a daemon account
+type Account struct {
+	Username  string
+	Password  string
+	AuthLevel AuthLevel
+}
+
+// GetKnownAccounts gets the daemon accounts. Requires an admin account
+func (d *Deluge) GetKnownAccounts() ([]Account, error) {
+	var rr []struct {
+		Username     string    `json:"username"`
+		Password     string    `json:"password"`
+		AuthLevelInt AuthLevel `json:"authlevel_int"`
+	}
+	if err := d.call("core.get_known_accounts", nil, &rr); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]Account, 0, len(rr))
+	for _, entry := range rr {
+		accounts = append(accounts, Account{
+			Username:  entry.Username,
+			Password:  entry.Password,
+			AuthLevel: entry.AuthLevelInt,
+		})
+	}
+	return accounts, nil
+}
+
+// CreateAccount creates a daemon account. Requires an admin account
+func (d *Deluge) CreateAccount(account Account) error {
+	if err := validateAccount(account); err != nil {
+		return err
+	}
+	return d.call("core.create_account",
+		[]interface{}{account.Username, account.Password, account.AuthLevel.String()}, nil)
+}
+
+// UpdateAccount changes the password and auth level of a daemon account.
+// Requires an admin account
+func (d *Deluge) UpdateAccount(account Account) error {
+	if err := validateAccount(account); err != nil {
+		return err
+	}
+	return d.call("core.update_account",
+		[]interface{}{account.Username, account.Password, account.AuthLevel.String()}, nil)
+}
+
+// RemoveAccount removes a daemon account given its username. Requires an admin account
+func (d *Deluge) RemoveAccount(username string) error {
+	return d.call("core.remove_account", []interface{}{username}, nil)
+}
+
+// GetAuthLevels gets the auth levels the daemon knows about, by name
+func (d *Deluge) GetAuthLevels() (map[string]AuthLevel, error) {
+	var rr []map[string]interface{}
+	if err := d.call("core.get_auth_levels_mappings", nil, &rr); err != nil {
+		return nil, err
+	}
+	if len(rr) == 0 {
+		return nil, fmt.Errorf("unexpected auth levels %v", rr)
+	}
+
+	levels := make(map[string]AuthLevel, len(rr[0]))
+	for name, level := range rr[0] {
+		if n, ok := level.(float64); ok {
+			levels[name] = AuthLevel(n)
+		}
+	}
+	return levels, nil
+}
+
+func validateAccount(account Account) error {
+	if len(account.Username) == 0 {
+		return fmt.Errorf("account username cannot be empty")
+	}
+	switch account.AuthLevel {
+	case AuthNone, AuthReadOnly, AuthNormal, AuthAdmin:
+		return nil
+	}
+	return fmt.Errorf("unknown auth level %d", account.AuthLevel)
+}
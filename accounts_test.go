@@ -0,0 +1,108 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestManagingAccounts(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_known_accounts": `
+		{
+		  "id": 2,
+		  "result": [
+		    {"username": "localclient", "password": "abc", "authlevel": "ADMIN", "authlevel_int": 10},
+		    {"username": "bob", "password": "secret", "authlevel": "READONLY", "authlevel_int": 1}
+		  ],
+		  "error": null
+		}`,
+		"core.create_account": `{"id": 3, "result": true, "error": null}`,
+		"core.remove_account": `{"id": 4, "result": true, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			accounts, err := client.GetKnownAccounts()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(accounts))
+			assert.Equal(t, delugeclient.Account{Username: "bob", Password: "secret", AuthLevel: delugeclient.AuthReadOnly},
+				accounts[1])
+
+			err = client.CreateAccount(delugeclient.Account{Username: "alice", Password: "pw", AuthLevel: delugeclient.AuthNormal})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `["alice","pw","NORMAL"]`, requests[2])
+
+			if err := client.CreateAccount(delugeclient.Account{Username: "eve", AuthLevel: 7}); err == nil {
+				t.Fail()
+			}
+			if err := client.RemoveAccount("bob"); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 4, len(requests))
+		})
+}
+
+func TestGettingAuthLevels(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_auth_levels_mappings": `
+		{
+		  "id": 2,
+		  "result": [
+		    {"NONE": 0, "READONLY": 1, "DEFAULT": 5, "NORMAL": 5, "ADMIN": 10},
+		    {"0": "NONE", "1": "READONLY", "5": "NORMAL", "10": "ADMIN"}
+		  ],
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			levels, err := client.GetAuthLevels()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, delugeclient.AuthAdmin, levels["ADMIN"])
+			assert.Equal(t, delugeclient.AuthNormal, levels["DEFAULT"])
+		})
+}
+
+func TestFilteringByOwner(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": `
+		{"id": 2, "result": {"asdfgh123456": {"name": "Some.Linux.Distro", "owner": "alice", "shared": true}}, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			statuses, err := client.GetStatusesFiltered(
+				delugeclient.Filter{Owner: "alice", Shared: delugeclient.Bool(true)},
+				delugeclient.FieldName, delugeclient.FieldOwner, delugeclient.FieldShared)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"owner":"alice","shared":[true]},["name","owner","shared"]]`, requests[1])
+			assert.Equal(t, "alice", statuses[0].Owner)
+			assert.Equal(t, true, statuses[0].Shared)
+		})
+}
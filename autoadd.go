@@ -0,0 +1,203 @@
+package delugeclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// AutoAddClient manages watch folders through the AutoAdd plugin, which has to be enabled
+type AutoAddClient struct {
+	deluge *Deluge
+}
+
+// WatchDir is a folder the daemon watches for .torrent files to add. Once
+// added, the .torrent files are deleted unless AppendExtension or CopyTorrent
+// is set. The optional settings left nil use the daemon defaults for the
+// torrents added. Speeds are in KiB/s and -1 means unlimited
+type WatchDir struct {
+	Id      int
+	Path    string
+	Enabled bool
+	Owner   string
+
+	// AppendExtension renames the added .torrent files appending it
+	AppendExtension *string
+	// CopyTorrent copies the added .torrent files to this folder
+	CopyTorrent *string
+	// DeleteCopyTorrent deletes the copy when its torrent is removed
+	DeleteCopyTorrent bool
+
+	DownloadLocation    *string
+	MoveCompleted       *bool
+	MoveCompletedPath   *string
+	Label               *string
+	AddPaused           *bool
+	QueueToTop          *bool
+	AutoManaged         *bool
+	MaxDownloadSpeed    *float64
+	MaxUploadSpeed      *float64
+	MaxConnections      *int
+	MaxUploadSlots      *int
+	StopAtRatio         *bool
+	StopRatio           *float64
+	RemoveAtRatio       *bool
+	PrioritizeFirstLast *bool
+	SeedMode            *bool
+}
+
+// AutoAdd returns the client of the AutoAdd plugin
+func (d *Deluge) AutoAdd() *AutoAddClient {
+	return &AutoAddClient{deluge: d}
+}
+
+// GetWatchDirs gets all watch folders ordered by id
+func (c *AutoAddClient) GetWatchDirs() ([]WatchDir, error) {
+	var rr map[string]WatchDir
+	if err := c.deluge.call("autoadd.get_watchdirs", nil, &rr); err != nil {
+		return nil, err
+	}
+
+	watchDirs := make([]WatchDir, 0, len(rr))
+	for id, watchDir := range rr {
+		watchDir.Id, _ = strconv.Atoi(id)
+		watchDirs = append(watchDirs, watchDir)
+	}
+	sort.Slice(watchDirs, func(i, j int) bool {
+		return watchDirs[i].Id < watchDirs[j].Id
+	})
+	return watchDirs, nil
+}
+
+// Add creates a watch folder and returns its id. The folder has to exist in the daemon host
+func (c *AutoAddClient) Add(watchDir WatchDir) (int, error) {
+	var id json.Number
+	if err := c.deluge.call("autoadd.add", []interface{}{watchDir}, &id); err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(id.String())
+	if err != nil {
+		return 0, fmt.Errorf("unexpected watch folder id '%s'", id)
+	}
+	return n, nil
+}
+
+// Remove deletes a watch folder given its id
+func (c *AutoAddClient) Remove(id int) error {
+	return c.deluge.call("autoadd.remove", []interface{}{id}, nil)
+}
+
+// SetOptions replaces the settings of a watch folder given its id
+func (c *AutoAddClient) SetOptions(id int, watchDir WatchDir) error {
+	return c.deluge.call("autoadd.set_options", []interface{}{id, watchDir}, nil)
+}
+
+// Enable starts watching a folder given its id
+func (c *AutoAddClient) Enable(id int) error {
+	return c.deluge.call("autoadd.enable_watchdir", []interface{}{id}, nil)
+}
+
+// Disable stops watching a folder given its id
+func (c *AutoAddClient) Disable(id int) error {
+	return c.deluge.call("autoadd.disable_watchdir", []interface{}{id}, nil)
+}
+
+// MarshalJSON encodes the watch folder as the plugin options, where every
+// optional setting goes along with a toggle telling whether it applies
+func (w WatchDir) MarshalJSON() ([]byte, error) {
+	options := map[string]interface{}{
+		"path":                       w.Path,
+		"enabled":                    w.Enabled,
+		"delete_copy_torrent_toggle": w.DeleteCopyTorrent,
+	}
+	if w.Owner != "" {
+		options["owner"] = w.Owner
+	}
+	putToggled(options, "append_extension", w.AppendExtension)
+	putToggled(options, "copy_torrent", w.CopyTorrent)
+	putToggled(options, "download_location", w.DownloadLocation)
+	putToggled(options, "move_completed", w.MoveCompleted)
+	if w.MoveCompletedPath != nil {
+		options["move_completed_path"] = *w.MoveCompletedPath
+	}
+	putToggled(options, "label", w.Label)
+	putToggled(options, "add_paused", w.AddPaused)
+	putToggled(options, "queue_to_top", w.QueueToTop)
+	putToggled(options, "auto_managed", w.AutoManaged)
+	putToggled(options, "max_download_speed", w.MaxDownloadSpeed)
+	putToggled(options, "max_upload_speed", w.MaxUploadSpeed)
+	putToggled(options, "max_connections", w.MaxConnections)
+	putToggled(options, "max_upload_slots", w.MaxUploadSlots)
+	putToggled(options, "stop_at_ratio", w.StopAtRatio)
+	putToggled(options, "stop_ratio", w.StopRatio)
+	putToggled(options, "remove_at_ratio", w.RemoveAtRatio)
+	putToggled(options, "prioritize_first_last", w.PrioritizeFirstLast)
+	if w.SeedMode != nil {
+		options["seed_mode"] = *w.SeedMode
+	}
+	return json.Marshal(options)
+}
+
+// UnmarshalJSON decodes the plugin options, leaving nil the optional
+// settings whose toggle is off
+func (w *WatchDir) UnmarshalJSON(data []byte) error {
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(data, &options); err != nil {
+		return err
+	}
+	json.Unmarshal(options["path"], &w.Path)
+	json.Unmarshal(options["enabled"], &w.Enabled)
+	json.Unmarshal(options["owner"], &w.Owner)
+	json.Unmarshal(options["delete_copy_torrent_toggle"], &w.DeleteCopyTorrent)
+	w.AppendExtension = toggled[string](options, "append_extension")
+	w.CopyTorrent = toggled[string](options, "copy_torrent")
+	w.DownloadLocation = toggled[string](options, "download_location")
+	w.MoveCompleted = toggled[bool](options, "move_completed")
+	if w.MoveCompleted != nil {
+		w.MoveCompletedPath = toggled[string](options, "move_completed_path")
+	}
+	w.Label = toggled[string](options, "label")
+	w.AddPaused = toggled[bool](options, "add_paused")
+	w.QueueToTop = toggled[bool](options, "queue_to_top")
+	w.AutoManaged = toggled[bool](options, "auto_managed")
+	w.MaxDownloadSpeed = toggled[float64](options, "max_download_speed")
+	w.MaxUploadSpeed = toggled[float64](options, "max_upload_speed")
+	w.MaxConnections = toggled[int](options, "max_connections")
+	w.MaxUploadSlots = toggled[int](options, "max_upload_slots")
+	w.StopAtRatio = toggled[bool](options, "stop_at_ratio")
+	w.StopRatio = toggled[float64](options, "stop_ratio")
+	w.RemoveAtRatio = toggled[bool](options, "remove_at_ratio")
+	w.PrioritizeFirstLast = toggled[bool](options, "prioritize_first_last")
+	if raw, found := options["seed_mode"]; found {
+		var seedMode bool
+		if json.Unmarshal(raw, &seedMode) == nil {
+			w.SeedMode = &seedMode
+		}
+	}
+	return nil
+}
+
+func putToggled[T any](options map[string]interface{}, key string, value *T) {
+	options[key+"_toggle"] = value != nil
+	if value != nil {
+		options[key] = *value
+	}
+}
+
+// toggled returns the value of key, or nil when its toggle is off. Keys
+// without toggle, such as move_completed_path, always apply
+func toggled[T any](options map[string]json.RawMessage, key string) *T {
+	if toggle, found := options[key+"_toggle"]; found && !isSet(toggle) {
+		return nil
+	}
+	raw, found := options[key]
+	if !found {
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,96 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestGettingWatchDirs(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"autoadd.get_watchdirs": `
+		{
+		  "id": 2,
+		  "result": {
+		    "2": {"path": "/srv/watch/movies", "enabled": false, "label_toggle": true, "label": "movies"},
+		    "1": {
+		      "path": "/srv/watch/tv",
+		      "enabled": true,
+		      "owner": "alice",
+		      "copy_torrent_toggle": true,
+		      "copy_torrent": "/srv/torrents",
+		      "append_extension_toggle": false,
+		      "append_extension": ".added",
+		      "move_completed_toggle": true,
+		      "move_completed": true,
+		      "move_completed_path": "/mnt/tv",
+		      "max_upload_speed_toggle": false,
+		      "max_upload_speed": 100.0
+		    }
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			watchDirs, err := client.AutoAdd().GetWatchDirs()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(watchDirs))
+			tv := watchDirs[0]
+			assert.Equal(t, 1, tv.Id)
+			assert.Equal(t, "/srv/watch/tv", tv.Path)
+			assert.Equal(t, true, tv.Enabled)
+			assert.Equal(t, "alice", tv.Owner)
+			assert.Equal(t, "/srv/torrents", *tv.CopyTorrent)
+			assert.Equal(t, (*string)(nil), tv.AppendExtension)
+			assert.Equal(t, "/mnt/tv", *tv.MoveCompletedPath)
+			assert.Equal(t, (*float64)(nil), tv.MaxUploadSpeed)
+			assert.Equal(t, "movies", *watchDirs[1].Label)
+		})
+}
+
+func TestAddingWatchDir(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"autoadd.add":             `{"id": 2, "result": 3, "error": null}`,
+		"autoadd.enable_watchdir": `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			id, err := client.AutoAdd().Add(delugeclient.WatchDir{
+				Path:      "/srv/watch/distros",
+				Label:     delugeclient.String("linux"),
+				AddPaused: delugeclient.Bool(true),
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 3, id)
+			assertRequestParams(t, `[{"add_paused":true,"add_paused_toggle":true,"append_extension_toggle":false,`+
+				`"auto_managed_toggle":false,"copy_torrent_toggle":false,"delete_copy_torrent_toggle":false,`+
+				`"download_location_toggle":false,"enabled":false,"label":"linux","label_toggle":true,`+
+				`"max_connections_toggle":false,"max_download_speed_toggle":false,"max_upload_slots_toggle":false,`+
+				`"max_upload_speed_toggle":false,"move_completed_toggle":false,"path":"/srv/watch/distros",`+
+				`"prioritize_first_last_toggle":false,"queue_to_top_toggle":false,"remove_at_ratio_toggle":false,`+
+				`"stop_at_ratio_toggle":false,"stop_ratio_toggle":false}]`, requests[1])
+
+			if err := client.AutoAdd().Enable(id); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[3]`, requests[2])
+		})
+}
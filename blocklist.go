@@ -0,0 +1,129 @@
+package delugeclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// BlocklistConfig is the configuration of the Blocklist plugin
+type BlocklistConfig struct {
+	Url            string `json:"url"`
+	LoadOnStart    bool   `json:"load_on_start"`
+	CheckAfterDays int    `json:"check_after_days"`
+	// ListType and ListCompression are detected from the list when empty
+	ListType        string   `json:"list_type"`
+	ListCompression string   `json:"list_compression"`
+	Timeout         int      `json:"timeout"`
+	TryTimes        int      `json:"try_times"`
+	Whitelisted     []string `json:"whitelisted"`
+}
+
+// BlocklistStatus is the state of the blocklist in the daemon
+type BlocklistStatus struct {
+	// State is "Idle", "Downloading" or "Importing"
+	State        string
+	UpToDate     bool
+	NumBlocked   int
+	NumWhited    int
+	FileProgress float64
+	FileUrl      string
+	FileSize     int64
+	FileType     string
+	LastUpdate   time.Time
+	Whitelisted  []string
+}
+
+// BlocklistClient manages the Blocklist plugin, which has to be enabled
+type BlocklistClient struct {
+	deluge *Deluge
+}
+
+// Blocklist returns the client of the Blocklist plugin
+func (d *Deluge) Blocklist() *BlocklistClient {
+	return &BlocklistClient{deluge: d}
+}
+
+// GetConfig gets the configuration of the Blocklist plugin
+func (c *BlocklistClient) GetConfig() (*BlocklistConfig, error) {
+	var config BlocklistConfig
+	if err := c.deluge.call("blocklist.get_config", nil, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SetConfig changes the configuration of the Blocklist plugin
+func (c *BlocklistClient) SetConfig(config BlocklistConfig) error {
+	if config.Whitelisted == nil {
+		config.Whitelisted = []string{}
+	}
+	return c.deluge.call("blocklist.set_config", []interface{}{config}, nil)
+}
+
+// CheckImport downloads and imports the blocklist when it is outdated, or
+// always when force is set. Failures to download or import the list are
+// returned as errors
+func (c *BlocklistClient) CheckImport(force bool) error {
+	return c.deluge.call("blocklist.check_import", []interface{}{force}, nil)
+}
+
+// GetStatus gets the state of the blocklist
+func (c *BlocklistClient) GetStatus() (*BlocklistStatus, error) {
+	var rr struct {
+		State        string          `json:"state"`
+		UpToDate     bool            `json:"up_to_date"`
+		NumBlocked   int             `json:"num_blocked"`
+		NumWhited    int             `json:"num_whited"`
+		FileProgress float64         `json:"file_progress"`
+		FileUrl      string          `json:"file_url"`
+		FileSize     float64         `json:"file_size"`
+		FileType     string          `json:"file_type"`
+		FileDate     json.RawMessage `json:"file_date"`
+		Whitelisted  []string        `json:"whitelisted"`
+	}
+	if err := c.deluge.call("blocklist.get_status", nil, &rr); err != nil {
+		return nil, err
+	}
+	return &BlocklistStatus{
+		State:        rr.State,
+		UpToDate:     rr.UpToDate,
+		NumBlocked:   rr.NumBlocked,
+		NumWhited:    rr.NumWhited,
+		FileProgress: rr.FileProgress,
+		FileUrl:      rr.FileUrl,
+		FileSize:     int64(rr.FileSize),
+		FileType:     rr.FileType,
+		LastUpdate:   decodeTime(rr.FileDate),
+		Whitelisted:  rr.Whitelisted,
+	}, nil
+}
+
+// ImportAndWait forces an import of the blocklist and polls its state every
+// interval until the daemon is idle again, returning the resulting status.
+// As the plugin does not report failed imports, ending with no blocked
+// entries is returned as an error
+func (c *BlocklistClient) ImportAndWait(ctx context.Context, interval time.Duration) (*BlocklistStatus, error) {
+	if err := c.CheckImport(true); err != nil {
+		return nil, err
+	}
+	for {
+		status, err := c.GetStatus()
+		if err != nil {
+			return nil, err
+		}
+		if status.State == "Idle" {
+			if status.NumBlocked == 0 {
+				return status, fmt.Errorf("blocklist %s imported no entries", status.FileUrl)
+			}
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
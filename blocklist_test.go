@@ -0,0 +1,92 @@
+package delugeclient_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfiguringBlocklist(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"blocklist.get_config": `
+		{
+		  "id": 2,
+		  "result": {
+		    "url": "https://example.org/level1.gz",
+		    "load_on_start": false,
+		    "check_after_days": 4,
+		    "list_compression": "",
+		    "list_type": "",
+		    "last_update": 0.0,
+		    "list_size": 0,
+		    "timeout": 180,
+		    "try_times": 3,
+		    "whitelisted": ["192.168.0.0/16"]
+		  },
+		  "error": null
+		}`,
+		"blocklist.set_config": `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			config, err := client.Blocklist().GetConfig()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, "https://example.org/level1.gz", config.Url)
+			assert.Equal(t, 4, config.CheckAfterDays)
+			assert.Equal(t, []string{"192.168.0.0/16"}, config.Whitelisted)
+
+			config.LoadOnStart = true
+			if err := client.Blocklist().SetConfig(*config); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"url":"https://example.org/level1.gz","load_on_start":true,"check_after_days":4,`+
+				`"list_type":"","list_compression":"","timeout":180,"try_times":3,"whitelisted":["192.168.0.0/16"]}]`,
+				requests[2])
+		})
+}
+
+func TestImportingBlocklistAndWaiting(t *testing.T) {
+	states := []string{"Downloading", "Importing", "Idle"}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		w.WriteHeader(200)
+		if !strings.Contains(string(body), "blocklist.get_status") {
+			io.WriteString(w, `{"id": 1, "result": true, "error": null}`)
+			return
+		}
+		io.WriteString(w, `{"id": 2, "result": {"state": "`+states[0]+`", "up_to_date": true,
+			"num_blocked": 250000, "num_whited": 1, "file_date": 1700000000.0, "file_type": "PeerGuardian"}, "error": null}`)
+		states = states[1:]
+	})
+
+	testflight.WithServer(handler, func(r *testflight.Requester) {
+		client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+		if err := client.Connect(); err != nil {
+			t.Fail()
+		}
+		status, err := client.Blocklist().ImportAndWait(context.Background(), time.Millisecond)
+		if err != nil {
+			fmt.Println(err)
+			t.Fail()
+		}
+		assert.Equal(t, 0, len(states))
+		assert.Equal(t, "Idle", status.State)
+		assert.Equal(t, 250000, status.NumBlocked)
+		assert.Equal(t, time.Unix(1700000000, 0), status.LastUpdate)
+	})
+}
@@ -0,0 +1,158 @@
+package delugeclient
+
+import (
+	"time"
+)
+
+// Snapshot is the status of a set of torrents at a point in time
+type Snapshot struct {
+	Time     time.Time
+	Torrents map[string]TorrentStatus
+}
+
+// FieldChange is the old and new value of a status field
+type FieldChange struct {
+	Field Field
+	Old   interface{}
+	New   interface{}
+}
+
+// TorrentChange is a torrent present in two snapshots whose status changed
+type TorrentChange struct {
+	Id       string
+	Previous TorrentStatus
+	Current  TorrentStatus
+	Fields   []FieldChange
+}
+
+// Changes is what happened to the torrents between two snapshots
+type Changes struct {
+	Added   []TorrentStatus
+	Removed []TorrentStatus
+	Changed []TorrentChange
+}
+
+// ChangeTracker polls the server and reports the changes since the previous poll
+//
+//	tracker := deluge.NewChangeTracker(delugeclient.Filter{}, delugeclient.FieldState, delugeclient.FieldProgress)
+//	for range time.Tick(10 * time.Second) {
+//		changes, err := tracker.Poll()
+//		...
+//	}
+type ChangeTracker struct {
+	deluge   *Deluge
+	filter   Filter
+	fields   []Field
+	previous *Snapshot
+}
+
+// NewSnapshot takes a snapshot of the given statuses
+func NewSnapshot(statuses []TorrentStatus) *Snapshot {
+	snapshot := &Snapshot{Time: time.Now(), Torrents: make(map[string]TorrentStatus, len(statuses))}
+	for _, status := range statuses {
+		snapshot.Torrents[status.Id] = status
+	}
+	return snapshot
+}
+
+// Diff returns the changes from previous to s, which is empty when previous is nil
+func (s *Snapshot) Diff(previous *Snapshot) Changes {
+	var changes Changes
+	if previous == nil {
+		previous = &Snapshot{}
+	}
+	for _, status := range sortedStatuses(s.Torrents) {
+		old, found := previous.Torrents[status.Id]
+		if !found {
+			changes.Added = append(changes.Added, status)
+			continue
+		}
+		if fields := diffStatus(old, status); len(fields) > 0 {
+			changes.Changed = append(changes.Changed,
+				TorrentChange{Id: status.Id, Previous: old, Current: status, Fields: fields})
+		}
+	}
+	for _, status := range sortedStatuses(previous.Torrents) {
+		if _, found := s.Torrents[status.Id]; !found {
+			changes.Removed = append(changes.Removed, status)
+		}
+	}
+	return changes
+}
+
+// Empty tells whether nothing changed
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// Field returns the change of field, if it changed
+func (c TorrentChange) Field(field Field) (FieldChange, bool) {
+	for _, change := range c.Fields {
+		if change.Field == field {
+			return change, true
+		}
+	}
+	return FieldChange{}, false
+}
+
+// StateChanged tells whether the torrent went through a state transition
+func (c TorrentChange) StateChanged() bool {
+	_, changed := c.Field(FieldState)
+	return changed
+}
+
+// ProgressDelta is how much the progress grew, in percentage points
+func (c TorrentChange) ProgressDelta() float64 {
+	return c.Current.Progress - c.Previous.Progress
+}
+
+// RatioDelta is how much the share ratio grew
+func (c TorrentChange) RatioDelta() float64 {
+	return c.Current.Ratio - c.Previous.Ratio
+}
+
+// NewChangeTracker tracks the given fields, or AllFields when none is given, of
+// the torrents matching filter
+func (d *Deluge) NewChangeTracker(filter Filter, fields ...Field) *ChangeTracker {
+	return &ChangeTracker{deluge: d, filter: filter, fields: fields}
+}
+
+// Poll takes a new snapshot and returns the changes since the previous one.
+// On the first poll every torrent is reported as added
+func (t *ChangeTracker) Poll() (Changes, error) {
+	statuses, err := t.deluge.GetStatusesFiltered(t.filter, t.fields...)
+	if err != nil {
+		return Changes{}, err
+	}
+	snapshot := NewSnapshot(statuses)
+	changes := snapshot.Diff(t.previous)
+	t.previous = snapshot
+	return changes, nil
+}
+
+// Snapshot returns the last snapshot taken, or nil before the first poll
+func (t *ChangeTracker) Snapshot() *Snapshot {
+	return t.previous
+}
+
+func diffStatus(old, current TorrentStatus) []FieldChange {
+	var changes []FieldChange
+	for _, field := range AllFields {
+		if !old.Has(field) || !current.Has(field) {
+			continue
+		}
+		oldValue, newValue := old.Value(field), current.Value(field)
+		if equalValues(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+func equalValues(a, b interface{}) bool {
+	if t, ok := a.(time.Time); ok {
+		return t.Equal(b.(time.Time))
+	}
+	return a == b
+}
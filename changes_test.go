@@ -0,0 +1,69 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestTrackingChanges(t *testing.T) {
+	polls := []string{
+		`{"id": 2, "result": {
+		    "asdfgh123456": {"name": "Some.Linux.Distro", "state": "Downloading", "progress": 40.0, "ratio": 0.1},
+		    "123456asdfgh": {"name": "Some.Video", "state": "Seeding", "progress": 100.0, "ratio": 1.5}
+		  }, "error": null}`,
+		`{"id": 3, "result": {
+		    "asdfgh123456": {"name": "Some.Linux.Distro", "state": "Seeding", "progress": 100.0, "ratio": 0.1},
+		    "zxcvbn987654": {"name": "Other.Distro", "state": "Queued", "progress": 0.0, "ratio": 0.0}
+		  }, "error": null}`,
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(200)
+		if len(polls) == 0 {
+			io.WriteString(w, `{"id": 1, "result": true, "error": null}`)
+			return
+		}
+		io.WriteString(w, polls[0])
+		polls = polls[1:]
+	})
+
+	testflight.WithServer(handler, func(r *testflight.Requester) {
+		client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+		tracker := client.NewChangeTracker(delugeclient.Filter{},
+			delugeclient.FieldName, delugeclient.FieldState, delugeclient.FieldProgress, delugeclient.FieldRatio)
+
+		changes, err := tracker.Poll()
+		if err != nil {
+			fmt.Println(err)
+			t.Fail()
+		}
+		assert.Equal(t, 2, len(changes.Added))
+		assert.Equal(t, 0, len(changes.Changed))
+
+		changes, err = tracker.Poll()
+		if err != nil {
+			fmt.Println(err)
+			t.Fail()
+		}
+		assert.Equal(t, 1, len(changes.Added))
+		assert.Equal(t, "zxcvbn987654", changes.Added[0].Id)
+		assert.Equal(t, 1, len(changes.Removed))
+		assert.Equal(t, "Some.Video", changes.Removed[0].Name)
+		assert.Equal(t, 1, len(changes.Changed))
+
+		change := changes.Changed[0]
+		assert.Equal(t, "asdfgh123456", change.Id)
+		assert.Equal(t, true, change.StateChanged())
+		assert.Equal(t, 60.0, change.ProgressDelta())
+		assert.Equal(t, 0.0, change.RatioDelta())
+		state, _ := change.Field(delugeclient.FieldState)
+		assert.Equal(t, "Downloading", state.Old)
+		assert.Equal(t, "Seeding", state.New)
+		_, ratioChanged := change.Field(delugeclient.FieldRatio)
+		assert.Equal(t, false, ratioChanged)
+	})
+}
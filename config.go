@@ -0,0 +1,148 @@
+package delugeclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CoreConfig is the daemon configuration. Only the fields that are set are
+// sent to the server and only the keys the server knows about are read
+// back, as they differ between Deluge versions. Speeds are in KiB/s and -1
+// means unlimited. Keys not modelled here are available through
+// GetConfigRaw and SetConfigRaw
+type CoreConfig struct {
+	// Bandwidth
+	MaxConnectionsGlobal       *int     `json:"max_connections_global,omitempty"`
+	MaxUploadSlotsGlobal       *int     `json:"max_upload_slots_global,omitempty"`
+	MaxDownloadSpeed           *float64 `json:"max_download_speed,omitempty"`
+	MaxUploadSpeed             *float64 `json:"max_upload_speed,omitempty"`
+	MaxHalfOpenConnections     *int     `json:"max_half_open_connections,omitempty"`
+	MaxConnectionsPerSecond    *int     `json:"max_connections_per_second,omitempty"`
+	MaxConnectionsPerTorrent   *int     `json:"max_connections_per_torrent,omitempty"`
+	MaxUploadSlotsPerTorrent   *int     `json:"max_upload_slots_per_torrent,omitempty"`
+	MaxDownloadSpeedPerTorrent *float64 `json:"max_download_speed_per_torrent,omitempty"`
+	MaxUploadSpeedPerTorrent   *float64 `json:"max_upload_speed_per_torrent,omitempty"`
+	IgnoreLimitsOnLocalNetwork *bool    `json:"ignore_limits_on_local_network,omitempty"`
+	RateLimitIpOverhead        *bool    `json:"rate_limit_ip_overhead,omitempty"`
+
+	// Queue
+	MaxActiveLimit        *int     `json:"max_active_limit,omitempty"`
+	MaxActiveDownloading  *int     `json:"max_active_downloading,omitempty"`
+	MaxActiveSeeding      *int     `json:"max_active_seeding,omitempty"`
+	DontCountSlowTorrents *bool    `json:"dont_count_slow_torrents,omitempty"`
+	QueueNewToTop         *bool    `json:"queue_new_to_top,omitempty"`
+	AutoManagePreferSeeds *bool    `json:"auto_manage_prefer_seeds,omitempty"`
+	StopSeedAtRatio       *bool    `json:"stop_seed_at_ratio,omitempty"`
+	StopSeedRatio         *float64 `json:"stop_seed_ratio,omitempty"`
+	RemoveSeedAtRatio     *bool    `json:"remove_seed_at_ratio,omitempty"`
+	ShareRatioLimit       *float64 `json:"share_ratio_limit,omitempty"`
+	SeedTimeRatioLimit    *float64 `json:"seed_time_ratio_limit,omitempty"`
+	SeedTimeLimit         *int     `json:"seed_time_limit,omitempty"`
+
+	// Paths
+	DownloadLocation     *string `json:"download_location,omitempty"`
+	MoveCompleted        *bool   `json:"move_completed,omitempty"`
+	MoveCompletedPath    *string `json:"move_completed_path,omitempty"`
+	CopyTorrentFile      *bool   `json:"copy_torrent_file,omitempty"`
+	TorrentFilesLocation *string `json:"torrentfiles_location,omitempty"`
+	DelCopyTorrentFile   *bool   `json:"del_copy_torrent_file,omitempty"`
+
+	// Defaults for new torrents
+	AddPaused                 *bool `json:"add_paused,omitempty"`
+	PreAllocateStorage        *bool `json:"pre_allocate_storage,omitempty"`
+	PrioritizeFirstLastPieces *bool `json:"prioritize_first_last_pieces,omitempty"`
+	SequentialDownload        *bool `json:"sequential_download,omitempty"`
+	SuperSeeding              *bool `json:"super_seeding,omitempty"`
+
+	// Network
+	ListenPorts         []int   `json:"listen_ports,omitempty"`
+	RandomPort          *bool   `json:"random_port,omitempty"`
+	ListenInterface     *string `json:"listen_interface,omitempty"`
+	OutgoingInterface   *string `json:"outgoing_interface,omitempty"`
+	OutgoingPorts       []int   `json:"outgoing_ports,omitempty"`
+	RandomOutgoingPorts *bool   `json:"random_outgoing_ports,omitempty"`
+	PeerTos             *string `json:"peer_tos,omitempty"`
+	AllowRemote         *bool   `json:"allow_remote,omitempty"`
+	DaemonPort          *int    `json:"daemon_port,omitempty"`
+
+	// Encryption, where policies are 0 forced, 1 enabled and 2 disabled, and
+	// levels 0 handshake, 1 full stream and 2 either
+	EncryptionInPolicy  *int  `json:"enc_in_policy,omitempty"`
+	EncryptionOutPolicy *int  `json:"enc_out_policy,omitempty"`
+	EncryptionLevel     *int  `json:"enc_level,omitempty"`
+	EncryptionPreferRc4 *bool `json:"enc_prefer_rc4,omitempty"`
+
+	// Proxy is replaced as a whole when set
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// Peer discovery and port mapping
+	Dht    *bool `json:"dht,omitempty"`
+	Upnp   *bool `json:"upnp,omitempty"`
+	Natpmp *bool `json:"natpmp,omitempty"`
+	Lsd    *bool `json:"lsd,omitempty"`
+	Utpex  *bool `json:"utpex,omitempty"`
+}
+
+// ProxyType is the kind of proxy the daemon connects through
+type ProxyType int
+
+const (
+	ProxyNone ProxyType = iota
+	ProxySocks4
+	ProxySocks5
+	ProxySocks5Auth
+	ProxyHttp
+	ProxyHttpAuth
+	ProxyI2pSam
+)
+
+// ProxyConfig is the proxy the daemon connects through
+type ProxyConfig struct {
+	Type                    ProxyType `json:"type"`
+	Hostname                string    `json:"hostname"`
+	Port                    int       `json:"port"`
+	Username                string    `json:"username"`
+	Password                string    `json:"password"`
+	ProxyHostnames          bool      `json:"proxy_hostnames"`
+	ProxyPeerConnections    bool      `json:"proxy_peer_connections"`
+	ProxyTrackerConnections bool      `json:"proxy_tracker_connections"`
+	ForceProxy              bool      `json:"force_proxy"`
+	AnonymousMode           bool      `json:"anonymous_mode"`
+}
+
+// GetConfig gets the daemon configuration
+func (d *Deluge) GetConfig() (*CoreConfig, error) {
+	var raw json.RawMessage
+	if err := d.call("core.get_config", nil, &raw); err != nil {
+		return nil, err
+	}
+	var config CoreConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse config. %s", err)
+	}
+	return &config, nil
+}
+
+// SetConfig changes the set fields of config in the daemon configuration
+func (d *Deluge) SetConfig(config CoreConfig) error {
+	return d.call("core.set_config", []interface{}{config}, nil)
+}
+
+// GetConfigRaw gets the whole daemon configuration as sent by the server
+func (d *Deluge) GetConfigRaw() (map[string]interface{}, error) {
+	var config map[string]interface{}
+	err := d.call("core.get_config", nil, &config)
+	return config, err
+}
+
+// GetConfigValues gets the given keys of the daemon configuration
+func (d *Deluge) GetConfigValues(keys ...string) (map[string]interface{}, error) {
+	var config map[string]interface{}
+	err := d.call("core.get_config_values", []interface{}{keys}, &config)
+	return config, err
+}
+
+// SetConfigRaw changes the given keys of the daemon configuration
+func (d *Deluge) SetConfigRaw(config map[string]interface{}) error {
+	return d.call("core.set_config", []interface{}{config}, nil)
+}
@@ -0,0 +1,87 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+const coreConfigResponse = `
+{
+  "id": 2,
+  "result": {
+    "max_download_speed": -1.0,
+    "max_active_downloading": 3,
+    "download_location": "/mnt/downloads",
+    "listen_ports": [6881, 6891],
+    "enc_in_policy": 1,
+    "dht": true,
+    "upnp": false,
+    "proxy": {"type": 2, "hostname": "proxy.lan", "port": 1080, "proxy_peer_connections": true},
+    "some_future_key": "value"
+  },
+  "error": null
+}`
+
+func TestGettingConfig(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_config": coreConfigResponse,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			config, err := client.GetConfig()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, -1.0, *config.MaxDownloadSpeed)
+			assert.Equal(t, 3, *config.MaxActiveDownloading)
+			assert.Equal(t, "/mnt/downloads", *config.DownloadLocation)
+			assert.Equal(t, []int{6881, 6891}, config.ListenPorts)
+			assert.Equal(t, false, *config.Upnp)
+			assert.Equal(t, delugeclient.ProxySocks5, config.Proxy.Type)
+			assert.Equal(t, (*bool)(nil), config.Lsd)
+
+			raw, err := client.GetConfigRaw()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, "value", raw["some_future_key"])
+		})
+}
+
+func TestSettingConfig(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.set_config": `{"id": 2, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			err := client.SetConfig(delugeclient.CoreConfig{
+				MaxUploadSpeed:       delugeclient.Float(500),
+				MaxActiveDownloading: delugeclient.Int(5),
+				Dht:                  delugeclient.Bool(false),
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"max_upload_speed":500,"max_active_downloading":5,"dht":false}]`, requests[1])
+
+			if err := client.SetConfigRaw(map[string]interface{}{"some_future_key": 1}); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"some_future_key":1}]`, requests[2])
+		})
+}
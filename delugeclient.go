@@ -12,6 +12,7 @@ import (
 	"net/http/cookiejar"
 	"os"
 	"reflect"
+	"strings"
 )
 
 type Deluge struct {
@@ -60,9 +61,11 @@ func (e RpcError) String() string {
 type Torrent struct {
 	Id         string
 	Name       string
+	Label      string
 	Progress   float64
 	ShareRatio float64
 	Files      []string
+	Tree       *FileTree
 }
 
 func (t *Torrent) String() string {
@@ -74,6 +77,7 @@ type TorrentEntry struct {
 	Progress float64 `json:"progress"`
 	Ratio    float64 `json:"ratio"`
 	Name     string  `json:"name"`
+	Label    string  `json:"label"`
 }
 
 type TorrentSet struct {
@@ -131,25 +135,46 @@ func (d *Deluge) Connect() error {
 	return nil
 }
 
-// AddMagnet adds a magnet/torrent link
-func (d *Deluge) AddMagnet(magnet string) error {
-	var payload = fmt.Sprintf(
-		`{"id":%d, "method":"web.add_torrents", "params":[[{"path":"%s", "options":""}]]}`,
-		d.Index, magnet)
-	var rr RpcResponseComplex
-	err := sendRequest(d.HttpClient, d.ServiceUrl, payload, &rr)
+// Logout ends the session with the server
+func (d *Deluge) Logout() error {
+	return d.call("auth.delete_session", nil, nil)
+}
 
-	if err != nil {
+// CheckSession tells whether the session with the server is still valid
+func (d *Deluge) CheckSession() (bool, error) {
+	var valid bool
+	err := d.call("auth.check_session", nil, &valid)
+	return valid, err
+}
+
+// ChangePassword changes the web password and keeps the new one for later connections
+func (d *Deluge) ChangePassword(oldPassword, newPassword string) error {
+	if len(newPassword) == 0 {
+		return errors.New("new password cannot be empty")
+	}
+	var changed bool
+	if err := d.call("auth.change_password", []interface{}{oldPassword, newPassword}, &changed); err != nil {
 		return err
 	}
-	if rr.Error.Code > 0 {
-		log.Println(rr)
-		return fmt.Errorf("error code %d! %s", rr.Error.Code, rr.Error.Message)
+	if !changed {
+		return errors.New("password not changed, the old password is wrong")
 	}
-	d.Index++
+	d.Password = newPassword
 	return nil
 }
 
+// AddMagnet adds a magnet/torrent link
+func (d *Deluge) AddMagnet(magnet string) error {
+	return d.AddMagnetWithOptions(magnet, TorrentOptions{})
+}
+
+// AddMagnetWithOptions adds a magnet/torrent link applying the set fields of
+// options, such as the file priorities, from the start
+func (d *Deluge) AddMagnetWithOptions(magnet string, options TorrentOptions) error {
+	torrents := []map[string]interface{}{{"path": magnet, "options": options}}
+	return d.call("web.add_torrents", []interface{}{torrents}, nil)
+}
+
 // MoveToQueueTop moves a torrent to the queue top
 func (d *Deluge) MoveToQueueTop(torrentId string) error {
 	var payload = fmt.Sprintf(
@@ -181,8 +206,11 @@ type TorrentContent struct {
 }
 
 type TorrentDetail struct {
+	Index           int                      `json:"index"`
 	Priority        int64                    `json:"priority"`
 	Path            string                   `json:"path"`
+	Size            int64                    `json:"size"`
+	Offset          int64                    `json:"offset"`
 	Type            string                   `json:"type"`
 	ShareRatio      float64                  `json:"ratio"`
 	Progress        float64                  `json:"progress"`
@@ -195,103 +223,92 @@ type Detail struct {
 
 // Get the link details about a single link given its hash id (torrentId)
 func (d *Deluge) Get(torrentId string) (*Torrent, error) {
-	var payload = fmt.Sprintf(
-		`{"id":%d, "method":"web.get_torrent_files", "params":["%s"]}`,
-		d.Index, torrentId)
-	var rr TorrentContent
-	err := sendRequest(d.HttpClient, d.ServiceUrl, payload, &rr)
-	if err != nil {
+	var rr TorrentResult
+	if err := d.call("web.get_torrent_files", []interface{}{torrentId}, &rr); err != nil {
 		return nil, err
 	}
-	if rr.Error.Code > 0 {
-		return nil, fmt.Errorf("error code %d! %s", rr.Error.Code, rr.Error.Message)
-	}
+	tree := newFileTree(rr)
 
-	if rr.TorrentResult.Type != "dir" {
-		return nil, nil
+	torrent := &Torrent{
+		Id:    torrentId,
+		Files: make([]string, 0),
+		Tree:  tree,
+	}
+	if len(tree.Nodes) == 0 {
+		return torrent, nil
 	}
 
-	if len(rr.TorrentResult.Contents) == 0 {
-		return &Torrent{
-			Id:         torrentId,
-			Files:      make([]string, 0),
-			ShareRatio: 0,
-		}, nil
+	root := tree.Nodes[0]
+	torrent.Name = root.Path
+	torrent.Progress = root.Progress
+	torrent.ShareRatio = rr.Contents[root.Name].ShareRatio
+	for _, file := range tree.Files() {
+		torrent.Files = append(torrent.Files, strings.TrimPrefix(file.Path, root.Path+"/"))
 	}
+	return torrent, nil
+}
 
-	for k, v := range rr.TorrentResult.Contents {
-
-		contents := rr.TorrentResult.Contents[k]
-		if len(contents.TorrentEntryMap) == 0 {
-			files := make([]string, 0, 1)
-			return &Torrent{
-				Id:         torrentId,
-				Name:       contents.Path,
-				Files:      append(files, contents.Path),
-				ShareRatio: contents.ShareRatio,
-			}, nil
-		}
+// GetAll gets the link details off all entries, ordered by name
+func (d *Deluge) GetAll() ([]Torrent, error) {
+	return d.GetAllFiltered(Filter{})
+}
 
-		files := make([]string, 0, len(contents.TorrentEntryMap))
-		for x, y := range contents.TorrentEntryMap {
+// RemoveOptions controls what happens to a torrent's data on removal
+type RemoveOptions struct {
+	DeleteData bool
+}
 
-			if y.Type == "file" {
-				//fmt.Println("type: ", y.Type, " key: ", x)
-				files = append(files, x)
-			}
-		}
-		d.Index++
-		return &Torrent{
-			Id:         torrentId,
-			Name:       v.Path,
-			Files:      files,
-			ShareRatio: v.ShareRatio,
-			Progress:   v.Progress,
-		}, nil
-	}
-	return nil, nil
+// TorrentError is the failure of an operation on a single torrent of a batch
+type TorrentError struct {
+	Id  string
+	Err error
 }
 
-// GetAll gets the link details off all entries
-func (d *Deluge) GetAll() ([]Torrent, error) {
-	var payload = fmt.Sprintf(
-		`{"id":%d, "method":"web.update_ui", "params":[["name", "ratio", "message", "progress"],{}]}`,
-		d.Index)
-	var rr AllResponse
-	err := sendRequest(d.HttpClient, d.ServiceUrl, payload, &rr)
+func (e TorrentError) Error() string {
+	return fmt.Sprintf("torrent %s: %s", e.Id, e.Err)
+}
+
+// Remove removes a link given its hash id (torrentId), deleting its downloaded data
+func (d *Deluge) Remove(torrentId string) error {
+	return d.RemoveWithOptions(torrentId, RemoveOptions{DeleteData: true})
+}
+
+// RemoveWithOptions removes a link given its hash id (torrentId), keeping its
+// downloaded data unless options.DeleteData is set
+func (d *Deluge) RemoveWithOptions(torrentId string, options RemoveOptions) error {
+	return d.call("core.remove_torrent", []interface{}{torrentId, options.DeleteData}, nil)
+}
+
+// RemoveMany removes several links at once and returns the ones that could not be removed.
+// Servers without core.remove_torrents (Deluge 1.3) get one core.remove_torrent call per link
+func (d *Deluge) RemoveMany(torrentIds []string, options RemoveOptions) ([]TorrentError, error) {
+	var rr [][]string
+	err := d.call("core.remove_torrents", []interface{}{torrentIds, options.DeleteData}, &rr)
+	if isUnknownMethod(err) {
+		return d.removeEach(torrentIds, options), nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	if rr.Error.Code > 0 {
-		log.Println(rr)
-		return nil, fmt.Errorf("error code %d! %s", rr.Error.Code, rr.Error.Message)
-	}
 
-	torrents := make([]Torrent, 0, len(rr.Torrents.Map))
-	for k, v := range rr.Torrents.Map {
-		torrents = append(torrents, Torrent{Id: k, Name: v.Name, ShareRatio: v.Ratio, Progress: v.Progress})
+	failed := make([]TorrentError, 0, len(rr))
+	for _, entry := range rr {
+		if len(entry) != 2 {
+			continue
+		}
+		failed = append(failed, TorrentError{Id: entry[0], Err: errors.New(entry[1])})
 	}
-	d.Index++
-	return torrents, nil
+	return failed, nil
 }
 
-// Remove removes a link given its hash id (torrentId)
-func (d *Deluge) Remove(torrentId string) error {
-	var payload = fmt.Sprintf(
-		`{"id":%d, "method":"core.remove_torrent", "params":["%s",true]}`,
-		d.Index, torrentId)
-	var rr RpcResponse
-	err := sendRequest(d.HttpClient, d.ServiceUrl, payload, &rr)
-	if err != nil {
-		return err
-	}
-
-	if rr.Error.Code > 0 {
-		log.Println(rr)
-		return fmt.Errorf("error code %d! %s", rr.Error.Code, rr.Error.Message)
+func (d *Deluge) removeEach(torrentIds []string, options RemoveOptions) []TorrentError {
+	failed := make([]TorrentError, 0)
+	for _, id := range torrentIds {
+		if err := d.RemoveWithOptions(id, options); err != nil {
+			failed = append(failed, TorrentError{Id: id, Err: err})
+		}
 	}
-	d.Index++
-	return nil
+	return failed
 }
 
 func sendRequest(httpClient http.Client, url, payload string, decoder interface{}) error {
@@ -309,3 +326,57 @@ func sendRequest(httpClient http.Client, url, payload string, decoder interface{
 
 	return nil
 }
+
+// Error codes returned by the Deluge web api
+const (
+	errorCodeUnknownMethod = 2
+)
+
+func (e *RpcError) Error() string {
+	return fmt.Sprintf("error code %d! %s", e.Code, e.Message)
+}
+
+func isUnknownMethod(err error) bool {
+	var rpcErr *RpcError
+	return errors.As(err, &rpcErr) && rpcErr.Code == errorCodeUnknownMethod
+}
+
+type rpcRequest struct {
+	Id     int           `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+type rpcResult struct {
+	Id     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RpcError       `json:"error"`
+}
+
+// call invokes method with params and decodes the result, if any, into result
+func (d *Deluge) call(method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	payload, err := json.Marshal(rpcRequest{Id: d.Index, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("unable to build %s request. %s", method, err)
+	}
+	var rr rpcResult
+	if err := sendRequest(d.HttpClient, d.ServiceUrl, string(payload), &rr); err != nil {
+		return err
+	}
+	if rr.Error != nil && rr.Error.Code > 0 {
+		log.Println(method, rr.Error)
+		return rr.Error
+	}
+	d.Index++
+
+	if result == nil || len(rr.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(rr.Result, result); err != nil {
+		return fmt.Errorf("unable to parse %s result. %s", method, err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package delugeclient_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/adelolmo/delugeclient"
 	"github.com/bmizerany/assert"
@@ -39,6 +40,67 @@ func TestConnectionWrongPassword(t *testing.T) {
 	})
 }
 
+func TestCheckingSessionAndLoggingOut(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"auth.check_session":  `{"id": 2, "result": true, "error": null}`,
+		"auth.delete_session": `{"id": 3, "result": true, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			valid, err := client.CheckSession()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, true, valid)
+			if err := client.Logout(); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 3, len(requests))
+		})
+}
+
+func TestChangingPassword(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"auth.change_password": `{"id": 2, "result": true, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			if err := client.ChangePassword("pass", "n3w"); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `["pass","n3w"]`, requests[1])
+			assert.Equal(t, "n3w", client.Password)
+		})
+}
+
+func TestChangingPasswordWrongOldPassword(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"auth.change_password": `{"id": 2, "result": false, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			if err := client.ChangePassword("xxx", "n3w"); err == nil {
+				t.Fail()
+			}
+			assert.Equal(t, "pass", client.Password)
+		})
+}
+
 func TestAddingMagnet(t *testing.T) {
 	testflight.WithServer(Handler(
 		`
@@ -264,6 +326,70 @@ func TestRemovingTorrent(t *testing.T) {
 		})
 }
 
+func TestRemovingTorrentKeepingData(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.remove_torrent": `{"id": 2, "result": true, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			if err := client.RemoveWithOptions("asdfgh123456", delugeclient.RemoveOptions{}); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(requests))
+			assertRequestParams(t, `["asdfgh123456",false]`, requests[1])
+		})
+}
+
+func TestRemovingManyTorrents(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.remove_torrents": `{"id": 2, "result": [["123456asdfgh", "torrent not found"]], "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			failed, err := client.RemoveMany([]string{"asdfgh123456", "123456asdfgh"},
+				delugeclient.RemoveOptions{DeleteData: true})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[["asdfgh123456","123456asdfgh"],true]`, requests[1])
+			assert.Equal(t, 1, len(failed))
+			assert.Equal(t, "123456asdfgh", failed[0].Id)
+			assert.Equal(t, "torrent not found", failed[0].Err.Error())
+		})
+}
+
+func TestRemovingManyTorrentsOnDeluge1(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.remove_torrent": `{"id": 2, "result": true, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			failed, err := client.RemoveMany([]string{"asdfgh123456", "123456asdfgh"}, delugeclient.RemoveOptions{})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 0, len(failed))
+			assert.Equal(t, 4, len(requests))
+			assertRequestParams(t, `["asdfgh123456",false]`, requests[2])
+			assertRequestParams(t, `["123456asdfgh",false]`, requests[3])
+		})
+}
+
 func WrongPasswordHandler() http.Handler {
 	m := pat.New()
 	m.Post("/json", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -288,6 +414,32 @@ func Handler(response string) http.Handler {
 	return m
 }
 
+// MethodHandler answers every rpc method with its own response, or with an
+// "Unknown method" error when there is none, and records the received payloads
+func MethodHandler(responses map[string]string, requests *[]string) http.Handler {
+	m := pat.New()
+	m.Post("/json", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		*requests = append(*requests, string(body))
+		var request struct {
+			Method string `json:"method"`
+		}
+		json.Unmarshal(body, &request)
+		w.WriteHeader(200)
+		if request.Method == "auth.login" {
+			io.WriteString(w, `{"id": 1, "result": true, "error": null}`)
+			return
+		}
+		response, found := responses[request.Method]
+		if !found {
+			io.WriteString(w, `{"id": 2, "result": null, "error": {"message": "Unknown method", "code": 2}}`)
+			return
+		}
+		io.WriteString(w, response)
+	}))
+	return m
+}
+
 func assertPanic(t *testing.T, f func()) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -297,6 +449,18 @@ func assertPanic(t *testing.T, f func()) {
 	f()
 }
 
+func assertRequestParams(t *testing.T, exp string, request string) {
+	var got struct {
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(request), &got); err != nil {
+		t.Fatal(err)
+	}
+	if exp != string(got.Params) {
+		t.Errorf("params %s, expected %s", got.Params, exp)
+	}
+}
+
 func assertContains(t *testing.T, exp []string, got []string) {
 	found := 0
 	for expItem := range exp {
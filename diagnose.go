@@ -0,0 +1,144 @@
+package delugeclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Check is the outcome of a single diagnostic check, with a hint on how to
+// fix it when it fails
+type Check struct {
+	Name   string
+	Passed bool
+	Detail string
+	Hint   string
+}
+
+// Diagnosis is the outcome of the network checks run by Diagnose
+type Diagnosis struct {
+	Checks     []Check
+	ListenPort int
+	ExternalIp string
+	Proxy      *ProxyConfig
+}
+
+// Passed tells whether every check passed
+func (d *Diagnosis) Passed() bool {
+	for _, check := range d.Checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Diagnosis) String() string {
+	var b strings.Builder
+	for _, check := range d.Checks {
+		result := "PASS"
+		if !check.Passed {
+			result = "FAIL"
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s\n", result, check.Name, check.Detail)
+		if !check.Passed && check.Hint != "" {
+			fmt.Fprintf(&b, "       %s\n", check.Hint)
+		}
+	}
+	return b.String()
+}
+
+// Diagnose checks why peers may not be able to connect to the daemon: whether
+// the web server is connected to it, its listen port is reachable from the
+// internet, its external address is known and it goes through a proxy.
+// Failed checks are part of the diagnosis, only transport errors are returned
+func (d *Deluge) Diagnose() (*Diagnosis, error) {
+	diagnosis := &Diagnosis{}
+	add := func(check Check, err error) error {
+		var rpcErr *RpcError
+		if err != nil && !errors.As(err, &rpcErr) {
+			return err
+		}
+		if err != nil {
+			check.Passed = false
+			check.Detail = rpcErr.Message
+		}
+		diagnosis.Checks = append(diagnosis.Checks, check)
+		return nil
+	}
+
+	var connected bool
+	err := d.call("web.connected", nil, &connected)
+	check := Check{Name: "daemon connection", Passed: connected, Detail: "web server connected to the daemon"}
+	if !connected {
+		check.Detail = "web server not connected to any daemon"
+		check.Hint = "connect the web UI to a daemon from its connection manager, or check that deluged is running"
+	}
+	if err := add(check, err); err != nil {
+		return nil, err
+	}
+	if !diagnosis.Checks[0].Passed {
+		return diagnosis, nil
+	}
+
+	port, err := d.GetListenPort()
+	diagnosis.ListenPort = port
+	check = Check{Name: "listen port", Passed: port > 0, Detail: fmt.Sprintf("listening on port %d", port)}
+	if port <= 0 {
+		check.Detail = "not listening for incoming connections"
+		check.Hint = "check the network interface and port range in the daemon network settings"
+	}
+	if err := add(check, err); err != nil {
+		return nil, err
+	}
+
+	var open *bool
+	err = d.call("core.test_listen_port", nil, &open)
+	check = Check{Name: "port reachability"}
+	switch {
+	case open == nil:
+		check.Detail = "the port test service could not be reached"
+		check.Hint = "retry later, the test relies on an external service"
+	case *open:
+		check.Passed = true
+		check.Detail = fmt.Sprintf("port %d is reachable from the internet", port)
+	default:
+		check.Detail = fmt.Sprintf("port %d is not reachable from the internet", port)
+		check.Hint = "forward the port in the router or enable UPnP/NAT-PMP, and allow it in the firewall"
+	}
+	if err := add(check, err); err != nil {
+		return nil, err
+	}
+
+	ip, err := d.GetExternalIp()
+	diagnosis.ExternalIp = ip
+	check = Check{Name: "external address", Passed: ip != "", Detail: "seen by peers as " + ip}
+	if ip == "" {
+		check.Detail = "external address not known yet"
+		check.Hint = "the address is learnt from trackers and peers, check that torrents announce successfully"
+	}
+	if isUnknownMethod(err) {
+		check = Check{Name: "external address", Passed: true, Detail: "not provided by this Deluge version"}
+		err = nil
+	}
+	if err := add(check, err); err != nil {
+		return nil, err
+	}
+
+	var proxy ProxyConfig
+	err = d.call("core.get_proxy", nil, &proxy)
+	check = Check{Name: "proxy", Passed: true, Detail: "no proxy"}
+	if err == nil && proxy.Type != ProxyNone {
+		diagnosis.Proxy = &proxy
+		check.Detail = fmt.Sprintf("connecting through %s:%d", proxy.Hostname, proxy.Port)
+		if proxy.ProxyPeerConnections && proxy.ForceProxy {
+			check.Passed = false
+			check.Detail += ", incoming peer connections are refused"
+			check.Hint = "disable the force proxy option to accept incoming peer connections"
+		}
+	}
+	if err := add(check, err); err != nil {
+		return nil, err
+	}
+	return diagnosis, nil
+}
@@ -0,0 +1,62 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"strings"
+	"testing"
+)
+
+func TestDiagnosing(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.connected":         `{"id": 2, "result": true, "error": null}`,
+		"core.get_listen_port":  `{"id": 3, "result": 6881, "error": null}`,
+		"core.test_listen_port": `{"id": 4, "result": false, "error": null}`,
+		"core.get_external_ip":  `{"id": 5, "result": "198.51.100.4", "error": null}`,
+		"core.get_proxy":        `{"id": 6, "result": {"type": 0, "hostname": "", "port": 8080}, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			diagnosis, err := client.Diagnose()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			fmt.Print(diagnosis)
+			assert.Equal(t, false, diagnosis.Passed())
+			assert.Equal(t, 5, len(diagnosis.Checks))
+			assert.Equal(t, 6881, diagnosis.ListenPort)
+			assert.Equal(t, "198.51.100.4", diagnosis.ExternalIp)
+			assert.Equal(t, (*delugeclient.ProxyConfig)(nil), diagnosis.Proxy)
+			assert.Equal(t, "port reachability", diagnosis.Checks[2].Name)
+			assert.Equal(t, false, diagnosis.Checks[2].Passed)
+			assert.Equal(t, true, strings.Contains(diagnosis.String(), "[FAIL] port reachability"))
+		})
+}
+
+func TestDiagnosingDisconnectedDaemon(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.connected": `{"id": 2, "result": false, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			diagnosis, err := client.Diagnose()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 1, len(diagnosis.Checks))
+			assert.Equal(t, false, diagnosis.Passed())
+			assert.Equal(t, 2, len(requests))
+		})
+}
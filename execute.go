@@ -0,0 +1,97 @@
+package delugeclient
+
+import (
+	"fmt"
+)
+
+// Events the Execute plugin runs commands on
+const (
+	EventTorrentAdded    = "added"
+	EventTorrentComplete = "complete"
+	EventTorrentRemoved  = "removed"
+)
+
+// ExecuteCommand is a command the daemon runs on an event, given the
+// torrent id, name and download location as arguments
+type ExecuteCommand struct {
+	Id      string
+	Event   string
+	Command string
+}
+
+// ExecuteClient manages commands through the Execute plugin, which has to be enabled
+type ExecuteClient struct {
+	deluge *Deluge
+}
+
+// Execute returns the client of the Execute plugin
+func (d *Deluge) Execute() *ExecuteClient {
+	return &ExecuteClient{deluge: d}
+}
+
+// GetCommands gets all commands
+func (c *ExecuteClient) GetCommands() ([]ExecuteCommand, error) {
+	var rr [][]string
+	if err := c.deluge.call("execute.get_commands", nil, &rr); err != nil {
+		return nil, err
+	}
+
+	commands := make([]ExecuteCommand, 0, len(rr))
+	for _, entry := range rr {
+		if len(entry) != 3 {
+			return nil, fmt.Errorf("unexpected command %v", entry)
+		}
+		commands = append(commands, ExecuteCommand{Id: entry[0], Event: entry[1], Command: entry[2]})
+	}
+	return commands, nil
+}
+
+// AddCommand adds a command to run on event
+func (c *ExecuteClient) AddCommand(event, command string) error {
+	return c.deluge.call("execute.add_command", []interface{}{event, command}, nil)
+}
+
+// RemoveCommand removes a command given its id
+func (c *ExecuteClient) RemoveCommand(id string) error {
+	return c.deluge.call("execute.remove_command", []interface{}{id}, nil)
+}
+
+// SaveCommand changes the event and command of an existing command
+func (c *ExecuteClient) SaveCommand(command ExecuteCommand) error {
+	return c.deluge.call("execute.save_command", []interface{}{command.Id, command.Event, command.Command}, nil)
+}
+
+// SyncCommands leaves the daemon with exactly the given commands, matched by
+// event and command, removing the rest. Ids of the given commands are ignored
+func (c *ExecuteClient) SyncCommands(commands []ExecuteCommand) error {
+	existing, err := c.GetCommands()
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[ExecuteCommand]bool, len(commands))
+	for _, command := range commands {
+		wanted[ExecuteCommand{Event: command.Event, Command: command.Command}] = true
+	}
+	for _, command := range existing {
+		key := ExecuteCommand{Event: command.Event, Command: command.Command}
+		if wanted[key] {
+			delete(wanted, key)
+			continue
+		}
+		if err := c.RemoveCommand(command.Id); err != nil {
+			return err
+		}
+	}
+	for _, command := range commands {
+		key := ExecuteCommand{Event: command.Event, Command: command.Command}
+		if !wanted[key] {
+			continue
+		}
+		if err := c.AddCommand(command.Event, command.Command); err != nil {
+			return err
+		}
+		delete(wanted, key)
+	}
+	return nil
+}
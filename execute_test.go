@@ -0,0 +1,64 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestSyncingCommands(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"execute.get_commands": `
+		{
+		  "id": 2,
+		  "result": [
+		    ["a1b2", "complete", "/usr/local/bin/notify.sh"],
+		    ["c3d4", "added", "/usr/local/bin/old.sh"]
+		  ],
+		  "error": null
+		}`,
+		"execute.remove_command": `{"id": 3, "result": null, "error": null}`,
+		"execute.add_command":    `{"id": 4, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			err := client.Execute().SyncCommands([]delugeclient.ExecuteCommand{
+				{Event: delugeclient.EventTorrentComplete, Command: "/usr/local/bin/notify.sh"},
+				{Event: delugeclient.EventTorrentRemoved, Command: "/usr/local/bin/cleanup.sh"},
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 4, len(requests))
+			assertRequestParams(t, `["c3d4"]`, requests[2])
+			assertRequestParams(t, `["removed","/usr/local/bin/cleanup.sh"]`, requests[3])
+		})
+}
+
+func TestSavingCommand(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"execute.save_command": `{"id": 2, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			err := client.Execute().SaveCommand(delugeclient.ExecuteCommand{
+				Id: "a1b2", Event: delugeclient.EventTorrentAdded, Command: "/usr/local/bin/notify.sh",
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `["a1b2","added","/usr/local/bin/notify.sh"]`, requests[1])
+		})
+}
@@ -0,0 +1,34 @@
+package delugeclient
+
+// ExtractorConfig is the configuration of the Extractor plugin, which
+// extracts the archives of completed torrents
+type ExtractorConfig struct {
+	// ExtractPath is where archives are extracted, the download location when empty
+	ExtractPath string `json:"extract_path"`
+	// UseNameFolder extracts into a folder named after the torrent
+	UseNameFolder bool `json:"use_name_folder"`
+}
+
+// ExtractorClient manages the Extractor plugin, which has to be enabled
+type ExtractorClient struct {
+	deluge *Deluge
+}
+
+// Extractor returns the client of the Extractor plugin
+func (d *Deluge) Extractor() *ExtractorClient {
+	return &ExtractorClient{deluge: d}
+}
+
+// GetConfig gets the configuration of the Extractor plugin
+func (c *ExtractorClient) GetConfig() (*ExtractorConfig, error) {
+	var config ExtractorConfig
+	if err := c.deluge.call("extractor.get_config", nil, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SetConfig replaces the configuration of the Extractor plugin
+func (c *ExtractorClient) SetConfig(config ExtractorConfig) error {
+	return c.deluge.call("extractor.set_config", []interface{}{config}, nil)
+}
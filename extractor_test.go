@@ -0,0 +1,36 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestConfiguringExtractor(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"extractor.get_config": `{"id": 2, "result": {"extract_path": "", "use_name_folder": true}, "error": null}`,
+		"extractor.set_config": `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			config, err := client.Extractor().GetConfig()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, true, config.UseNameFolder)
+
+			config.ExtractPath = "/mnt/extracted"
+			if err := client.Extractor().SetConfig(*config); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"extract_path":"/mnt/extracted","use_name_folder":true}]`, requests[2])
+		})
+}
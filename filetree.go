@@ -0,0 +1,112 @@
+package delugeclient
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// SkipDir is returned by a WalkFunc to skip the children of a directory
+var SkipDir = errors.New("skip this directory")
+
+// FileNode is a directory or a file inside a torrent. Directories have no
+// index, offset or priority, and their size is the one of all their files
+type FileNode struct {
+	Name     string
+	Path     string
+	Dir      bool
+	Index    int
+	Size     int64
+	Offset   int64
+	Progress float64
+	Priority FilePriority
+	Children []*FileNode
+}
+
+// FileTree is the content of a torrent, the top level entries ordered by name
+type FileTree struct {
+	Nodes []*FileNode
+}
+
+// WalkFunc is called for every node of a FileTree
+type WalkFunc func(node *FileNode) error
+
+// Walk calls fn for every node of the tree, depth first and ordered by name.
+// It stops at the first error fn returns, other than SkipDir
+func (t *FileTree) Walk(fn WalkFunc) error {
+	return walkNodes(t.Nodes, fn)
+}
+
+// Files returns the files of the tree ordered by index
+func (t *FileTree) Files() []*FileNode {
+	files := make([]*FileNode, 0)
+	t.Walk(func(node *FileNode) error {
+		if !node.Dir {
+			files = append(files, node)
+		}
+		return nil
+	})
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Index < files[j].Index
+	})
+	return files
+}
+
+// GetFileTree gets the file tree of a torrent given its hash id (torrentId)
+func (d *Deluge) GetFileTree(torrentId string) (*FileTree, error) {
+	var rr TorrentResult
+	if err := d.call("web.get_torrent_files", []interface{}{torrentId}, &rr); err != nil {
+		return nil, err
+	}
+	return newFileTree(rr), nil
+}
+
+func newFileTree(result TorrentResult) *FileTree {
+	return &FileTree{Nodes: newFileNodes(result.Contents)}
+}
+
+func newFileNodes(contents map[string]TorrentDetail) []*FileNode {
+	nodes := make([]*FileNode, 0, len(contents))
+	for name, detail := range contents {
+		nodes = append(nodes, newFileNode(name, detail))
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
+func newFileNode(name string, detail TorrentDetail) *FileNode {
+	node := &FileNode{
+		Name:     name,
+		Path:     strings.TrimSuffix(detail.Path, "/"),
+		Dir:      detail.Type == "dir",
+		Index:    -1,
+		Size:     detail.Size,
+		Progress: detail.Progress,
+	}
+	if node.Dir {
+		node.Children = newFileNodes(detail.TorrentEntryMap)
+		return node
+	}
+	node.Index = detail.Index
+	node.Offset = detail.Offset
+	node.Priority = FilePriority(detail.Priority)
+	return node
+}
+
+func walkNodes(nodes []*FileNode, fn WalkFunc) error {
+	for _, node := range nodes {
+		err := fn(node)
+		if err == SkipDir && node.Dir {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := walkNodes(node.Children, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+const nestedFilesResponse = `
+{
+  "id": 2,
+  "result": {
+    "type": "dir",
+    "contents": {
+      "Some.Show.S01": {
+        "path": "Some.Show.S01",
+        "type": "dir",
+        "size": 3000000060,
+        "progress": 50.0,
+        "contents": {
+          "Episodes": {
+            "path": "Some.Show.S01/Episodes",
+            "type": "dir",
+            "size": 3000000000,
+            "contents": {
+              "E02.mkv": {"index": 2, "offset": 1500000030, "path": "Some.Show.S01/Episodes/E02.mkv", "priority": 0, "progress": 0.0, "size": 1500000000, "type": "file"},
+              "E01.mkv": {"index": 1, "offset": 30, "path": "Some.Show.S01/Episodes/E01.mkv", "priority": 4, "progress": 100.0, "size": 1500000000, "type": "file"}
+            }
+          },
+          "README.txt": {"index": 0, "offset": 0, "path": "Some.Show.S01/README.txt", "priority": 7, "progress": 100.0, "size": 30, "type": "file"},
+          "Extras": {
+            "path": "Some.Show.S01/Extras",
+            "type": "dir",
+            "size": 30,
+            "contents": {
+              "Deep": {
+                "path": "Some.Show.S01/Extras/Deep",
+                "type": "dir",
+                "size": 30,
+                "contents": {
+                  "notes.txt": {"index": 3, "offset": 3000000030, "path": "Some.Show.S01/Extras/Deep/notes.txt", "priority": 4, "progress": 100.0, "size": 30, "type": "file"}
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "error": null
+}`
+
+func TestGettingFileTree(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.get_torrent_files": nestedFilesResponse,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			tree, err := client.GetFileTree("asdfgh123456")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			files := tree.Files()
+			assert.Equal(t, 4, len(files))
+			assert.Equal(t, "Some.Show.S01/README.txt", files[0].Path)
+			assert.Equal(t, delugeclient.PriorityHigh, files[0].Priority)
+			assert.Equal(t, "E02.mkv", files[2].Name)
+			assert.Equal(t, int64(1500000030), files[2].Offset)
+			assert.Equal(t, delugeclient.PrioritySkip, files[2].Priority)
+			assert.Equal(t, "Some.Show.S01/Extras/Deep/notes.txt", files[3].Path)
+
+			var visited []string
+			tree.Walk(func(node *delugeclient.FileNode) error {
+				visited = append(visited, node.Path)
+				if node.Name == "Extras" {
+					return delugeclient.SkipDir
+				}
+				return nil
+			})
+			assert.Equal(t, []string{
+				"Some.Show.S01",
+				"Some.Show.S01/Episodes",
+				"Some.Show.S01/Episodes/E01.mkv",
+				"Some.Show.S01/Episodes/E02.mkv",
+				"Some.Show.S01/Extras",
+				"Some.Show.S01/README.txt",
+			}, visited)
+		})
+}
+
+func TestGettingNestedFiles(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.get_torrent_files": nestedFilesResponse,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			torrent, err := client.Get("asdfgh123456")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, "Some.Show.S01", torrent.Name)
+			assert.Equal(t, 50.0, torrent.Progress)
+			assert.Equal(t, []string{"README.txt", "Episodes/E01.mkv", "Episodes/E02.mkv", "Extras/Deep/notes.txt"},
+				torrent.Files)
+			assert.Equal(t, int64(3000000060), torrent.Tree.Nodes[0].Size)
+		})
+}
@@ -0,0 +1,113 @@
+package delugeclient
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Filter narrows down the torrents returned by the server. Empty fields do not filter
+type Filter struct {
+	State       string
+	Label       string
+	TrackerHost string
+	Owner       string
+	Shared      *bool
+	Ids         []string
+}
+
+// FilterCount is a filter value and the number of torrents matching it
+type FilterCount struct {
+	Value string
+	Count int
+}
+
+// FilterTree holds the torrent counts per state, label, tracker and owner
+type FilterTree struct {
+	State       []FilterCount
+	Label       []FilterCount
+	TrackerHost []FilterCount
+	Owner       []FilterCount
+}
+
+func (f Filter) dict() map[string]interface{} {
+	dict := make(map[string]interface{})
+	if f.State != "" && f.State != "All" {
+		dict["state"] = f.State
+	}
+	if f.Label != "" {
+		dict["label"] = f.Label
+	}
+	if f.TrackerHost != "" {
+		dict["tracker_host"] = f.TrackerHost
+	}
+	if f.Owner != "" {
+		dict["owner"] = f.Owner
+	}
+	if f.Shared != nil {
+		// the server matches keys it has no filter for against a list of values
+		dict["shared"] = []bool{*f.Shared}
+	}
+	if len(f.Ids) > 0 {
+		dict["id"] = f.Ids
+	}
+	return dict
+}
+
+// GetAllFiltered gets the link details of the entries matching filter, ordered by name
+func (d *Deluge) GetAllFiltered(filter Filter) ([]Torrent, error) {
+	var rr TorrentSet
+	keys := []string{"name", "ratio", "message", "progress", "label"}
+	if err := d.call("web.update_ui", []interface{}{keys, filter.dict()}, &rr); err != nil {
+		return nil, err
+	}
+
+	torrents := make([]Torrent, 0, len(rr.Map))
+	for k, v := range rr.Map {
+		torrents = append(torrents, Torrent{Id: k, Name: v.Name, Label: v.Label, ShareRatio: v.Ratio, Progress: v.Progress})
+	}
+	sort.Slice(torrents, func(i, j int) bool {
+		if torrents[i].Name != torrents[j].Name {
+			return torrents[i].Name < torrents[j].Name
+		}
+		return torrents[i].Id < torrents[j].Id
+	})
+	return torrents, nil
+}
+
+// GetStatusesFiltered gets the given status fields, or AllFields when none is
+// given, of the torrents matching filter, ordered by name
+func (d *Deluge) GetStatusesFiltered(filter Filter, fields ...Field) ([]TorrentStatus, error) {
+	var rr map[string]TorrentStatus
+	err := d.call("core.get_torrents_status", []interface{}{filter.dict(), statusKeys(fields)}, &rr)
+	if err != nil {
+		return nil, err
+	}
+	return sortedStatuses(rr), nil
+}
+
+// GetFilterTree gets the number of torrents per state, label, tracker and owner
+func (d *Deluge) GetFilterTree() (*FilterTree, error) {
+	var rr map[string][][]interface{}
+	if err := d.call("core.get_filter_tree", []interface{}{true, []string{}}, &rr); err != nil {
+		return nil, err
+	}
+
+	counts := func(category string) []FilterCount {
+		entries := rr[category]
+		result := make([]FilterCount, 0, len(entries))
+		for _, entry := range entries {
+			if len(entry) != 2 {
+				continue
+			}
+			count, _ := entry[1].(float64)
+			result = append(result, FilterCount{Value: fmt.Sprint(entry[0]), Count: int(count)})
+		}
+		return result
+	}
+	return &FilterTree{
+		State:       counts("state"),
+		Label:       counts("label"),
+		TrackerHost: counts("tracker_host"),
+		Owner:       counts("owner"),
+	}, nil
+}
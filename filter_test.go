@@ -0,0 +1,101 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestGettingAllFiltered(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.update_ui": `
+		{
+		  "id": 2,
+		  "result": {
+		    "torrents": {
+		      "asdfgh123456": {"message": "OK", "ratio": 2.5, "name": "Some.Show.S01E01", "label": "tv"}
+		    }
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			torrents, err := client.GetAllFiltered(delugeclient.Filter{State: "Seeding", Label: "tv"})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t,
+				`[["name","ratio","message","progress","label"],{"label":"tv","state":"Seeding"}]`, requests[1])
+			assert.Equal(t, 1, len(torrents))
+			assert.Equal(t, "Some.Show.S01E01", torrents[0].Name)
+			assert.Equal(t, "tv", torrents[0].Label)
+		})
+}
+
+func TestGettingStatusesFiltered(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": `
+		{"id": 2, "result": {"asdfgh123456": {"name": "Some.Linux.Distro", "tracker_host": "tracker.net"}}, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			statuses, err := client.GetStatusesFiltered(
+				delugeclient.Filter{TrackerHost: "tracker.net", Owner: "alice", Ids: []string{"asdfgh123456"}},
+				delugeclient.FieldName, delugeclient.FieldTrackerHost)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t,
+				`[{"id":["asdfgh123456"],"owner":"alice","tracker_host":"tracker.net"},["name","tracker_host"]]`,
+				requests[1])
+			assert.Equal(t, 1, len(statuses))
+			assert.Equal(t, "tracker.net", statuses[0].TrackerHost)
+		})
+}
+
+func TestGettingFilterTree(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_filter_tree": `
+		{
+		  "id": 2,
+		  "result": {
+		    "state": [["All", 4], ["Downloading", 1], ["Seeding", 3]],
+		    "label": [["", 1], ["tv", 3]],
+		    "tracker_host": [["All", 4], ["tracker.net", 4]],
+		    "owner": [["alice", 4]]
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			tree, err := client.GetFilterTree()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, []delugeclient.FilterCount{
+				{Value: "All", Count: 4}, {Value: "Downloading", Count: 1}, {Value: "Seeding", Count: 3},
+			}, tree.State)
+			assert.Equal(t, delugeclient.FilterCount{Value: "tv", Count: 3}, tree.Label[1])
+			assert.Equal(t, 4, tree.TrackerHost[1].Count)
+			assert.Equal(t, "alice", tree.Owner[0].Value)
+		})
+}
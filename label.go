@@ -0,0 +1,93 @@
+package delugeclient
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// validLabel is what the Label plugin accepts as a label name
+var validLabel = regexp.MustCompile(`^[a-z0-9_\-.]+$`)
+
+// LabelClient manages labels through the Label plugin, which has to be enabled
+type LabelClient struct {
+	deluge *Deluge
+}
+
+// LabelOptions are the settings applied to the torrents of a label. Only the
+// fields that are set are sent to the server. Speeds are in KiB/s and -1
+// means unlimited
+type LabelOptions struct {
+	ApplyMax            *bool    `json:"apply_max,omitempty"`
+	MaxDownloadSpeed    *float64 `json:"max_download_speed,omitempty"`
+	MaxUploadSpeed      *float64 `json:"max_upload_speed,omitempty"`
+	MaxConnections      *int     `json:"max_connections,omitempty"`
+	MaxUploadSlots      *int     `json:"max_upload_slots,omitempty"`
+	PrioritizeFirstLast *bool    `json:"prioritize_first_last,omitempty"`
+	ApplyQueue          *bool    `json:"apply_queue,omitempty"`
+	IsAutoManaged       *bool    `json:"is_auto_managed,omitempty"`
+	StopAtRatio         *bool    `json:"stop_at_ratio,omitempty"`
+	StopRatio           *float64 `json:"stop_ratio,omitempty"`
+	RemoveAtRatio       *bool    `json:"remove_at_ratio,omitempty"`
+	ApplyMoveCompleted  *bool    `json:"apply_move_completed,omitempty"`
+	MoveCompleted       *bool    `json:"move_completed,omitempty"`
+	MoveCompletedPath   *string  `json:"move_completed_path,omitempty"`
+	AutoAdd             *bool    `json:"auto_add,omitempty"`
+	AutoAddTrackers     []string `json:"auto_add_trackers,omitempty"`
+}
+
+// Labels returns the client of the Label plugin
+func (d *Deluge) Labels() *LabelClient {
+	return &LabelClient{deluge: d}
+}
+
+// GetLabels gets the names of all labels
+func (c *LabelClient) GetLabels() ([]string, error) {
+	var labels []string
+	err := c.deluge.call("label.get_labels", nil, &labels)
+	return labels, err
+}
+
+// Add creates a label. Names can only have lowercase letters, digits, '_', '-' and '.'
+func (c *LabelClient) Add(label string) error {
+	if !validLabel.MatchString(label) {
+		return fmt.Errorf("invalid label name '%s'", label)
+	}
+	return c.deluge.call("label.add", []interface{}{label}, nil)
+}
+
+// Remove deletes a label, unlabelling its torrents
+func (c *LabelClient) Remove(label string) error {
+	return c.deluge.call("label.remove", []interface{}{label}, nil)
+}
+
+// SetTorrent labels a torrent given its hash id (torrentId). An empty label
+// removes the label of the torrent
+func (c *LabelClient) SetTorrent(torrentId, label string) error {
+	return c.deluge.call("label.set_torrent", []interface{}{torrentId, label}, nil)
+}
+
+// GetOptions gets the settings of a label
+func (c *LabelClient) GetOptions(label string) (*LabelOptions, error) {
+	var options LabelOptions
+	if err := c.deluge.call("label.get_options", []interface{}{label}, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// SetOptions changes the set fields of options in the settings of a label
+func (c *LabelClient) SetOptions(label string, options LabelOptions) error {
+	return c.deluge.call("label.set_options", []interface{}{label, options}, nil)
+}
+
+// GetConfig gets the global configuration of the Label plugin
+func (c *LabelClient) GetConfig() (map[string]interface{}, error) {
+	var config map[string]interface{}
+	err := c.deluge.call("label.get_config", nil, &config)
+	return config, err
+}
+
+// SetConfig changes the given keys of the global configuration of the Label plugin
+func (c *LabelClient) SetConfig(config map[string]interface{}) error {
+	return c.deluge.call("label.set_config", []interface{}{config}, nil)
+}
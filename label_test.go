@@ -0,0 +1,95 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestManagingLabels(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"label.get_labels":  `{"id": 2, "result": ["movies", "tv"], "error": null}`,
+		"label.add":         `{"id": 3, "result": null, "error": null}`,
+		"label.set_torrent": `{"id": 4, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			labels := client.Labels()
+			names, err := labels.GetLabels()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, []string{"movies", "tv"}, names)
+
+			if err := labels.Add("Linux Distros"); err == nil {
+				t.Fail()
+			}
+			if err := labels.Add("linux-distros"); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			if err := labels.SetTorrent("asdfgh123456", "linux-distros"); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 4, len(requests))
+			assertRequestParams(t, `["linux-distros"]`, requests[2])
+			assertRequestParams(t, `["asdfgh123456","linux-distros"]`, requests[3])
+		})
+}
+
+func TestSettingLabelOptions(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"label.set_options": `{"id": 2, "result": null, "error": null}`,
+		"label.get_options": `
+		{
+		  "id": 3,
+		  "result": {
+		    "apply_max": true,
+		    "max_download_speed": 2048.0,
+		    "apply_move_completed": true,
+		    "move_completed": true,
+		    "move_completed_path": "/mnt/tv",
+		    "auto_add": true,
+		    "auto_add_trackers": ["tracker.tv.org"]
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			err := client.Labels().SetOptions("tv", delugeclient.LabelOptions{
+				ApplyMoveCompleted: delugeclient.Bool(true),
+				MoveCompleted:      delugeclient.Bool(true),
+				MoveCompletedPath:  delugeclient.String("/mnt/tv"),
+				AutoAddTrackers:    []string{"tracker.tv.org"},
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t,
+				`["tv",{"apply_move_completed":true,"move_completed":true,"move_completed_path":"/mnt/tv","auto_add_trackers":["tracker.tv.org"]}]`,
+				requests[1])
+
+			options, err := client.Labels().GetOptions("tv")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2048.0, *options.MaxDownloadSpeed)
+			assert.Equal(t, "/mnt/tv", *options.MoveCompletedPath)
+			assert.Equal(t, []string{"tracker.tv.org"}, options.AutoAddTrackers)
+		})
+}
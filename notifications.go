@@ -0,0 +1,47 @@
+package delugeclient
+
+// NotificationsConfig is the daemon side configuration of the Notifications
+// plugin, which sends emails on events
+type NotificationsConfig struct {
+	SmtpEnabled    bool     `json:"smtp_enabled"`
+	SmtpHost       string   `json:"smtp_host"`
+	SmtpPort       int      `json:"smtp_port"`
+	SmtpUser       string   `json:"smtp_user"`
+	SmtpPassword   string   `json:"smtp_pass"`
+	SmtpFrom       string   `json:"smtp_from"`
+	SmtpTls        bool     `json:"smtp_tls"`
+	SmtpRecipients []string `json:"smtp_recipients"`
+	// Subscriptions are the event names, such as "TorrentFinishedEvent",
+	// notified by each kind of notification, such as "email"
+	Subscriptions map[string][]string `json:"subscriptions"`
+}
+
+// NotificationsClient manages the Notifications plugin, which has to be enabled
+type NotificationsClient struct {
+	deluge *Deluge
+}
+
+// Notifications returns the client of the Notifications plugin
+func (d *Deluge) Notifications() *NotificationsClient {
+	return &NotificationsClient{deluge: d}
+}
+
+// GetConfig gets the configuration of the Notifications plugin
+func (c *NotificationsClient) GetConfig() (*NotificationsConfig, error) {
+	var config NotificationsConfig
+	if err := c.deluge.call("notifications.get_config", nil, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SetConfig replaces the configuration of the Notifications plugin
+func (c *NotificationsClient) SetConfig(config NotificationsConfig) error {
+	if config.SmtpRecipients == nil {
+		config.SmtpRecipients = []string{}
+	}
+	if config.Subscriptions == nil {
+		config.Subscriptions = map[string][]string{}
+	}
+	return c.deluge.call("notifications.set_config", []interface{}{config}, nil)
+}
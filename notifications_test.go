@@ -0,0 +1,56 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestConfiguringNotifications(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"notifications.get_config": `
+		{
+		  "id": 2,
+		  "result": {
+		    "smtp_enabled": true,
+		    "smtp_host": "smtp.example.org",
+		    "smtp_port": 587,
+		    "smtp_user": "deluge",
+		    "smtp_pass": "secret",
+		    "smtp_from": "deluge@example.org",
+		    "smtp_tls": true,
+		    "smtp_recipients": ["ops@example.org"],
+		    "subscriptions": {"email": ["TorrentFinishedEvent"]}
+		  },
+		  "error": null
+		}`,
+		"notifications.set_config": `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			config, err := client.Notifications().GetConfig()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 587, config.SmtpPort)
+			assert.Equal(t, "secret", config.SmtpPassword)
+			assert.Equal(t, []string{"ops@example.org"}, config.SmtpRecipients)
+			assert.Equal(t, []string{"TorrentFinishedEvent"}, config.Subscriptions["email"])
+
+			err = client.Notifications().SetConfig(delugeclient.NotificationsConfig{SmtpHost: "smtp.example.org"})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"smtp_enabled":false,"smtp_host":"smtp.example.org","smtp_port":0,`+
+				`"smtp_user":"","smtp_pass":"","smtp_from":"","smtp_tls":false,"smtp_recipients":[],"subscriptions":{}}]`,
+				requests[2])
+		})
+}
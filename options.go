@@ -0,0 +1,74 @@
+package delugeclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TorrentOptions are the per torrent settings. Only the fields that are set
+// are sent to the server, leaving the rest untouched. Speeds are in KiB/s and
+// -1 means unlimited
+type TorrentOptions struct {
+	MaxDownloadSpeed          *float64       `json:"max_download_speed,omitempty"`
+	MaxUploadSpeed            *float64       `json:"max_upload_speed,omitempty"`
+	MaxConnections            *int           `json:"max_connections,omitempty"`
+	MaxUploadSlots            *int           `json:"max_upload_slots,omitempty"`
+	StopAtRatio               *bool          `json:"stop_at_ratio,omitempty"`
+	StopRatio                 *float64       `json:"stop_ratio,omitempty"`
+	RemoveAtRatio             *bool          `json:"remove_at_ratio,omitempty"`
+	AutoManaged               *bool          `json:"auto_managed,omitempty"`
+	SequentialDownload        *bool          `json:"sequential_download,omitempty"`
+	SuperSeeding              *bool          `json:"super_seeding,omitempty"`
+	PrioritizeFirstLastPieces *bool          `json:"prioritize_first_last_pieces,omitempty"`
+	MoveCompleted             *bool          `json:"move_completed,omitempty"`
+	MoveCompletedPath         *string        `json:"move_completed_path,omitempty"`
+	FilePriorities            []FilePriority `json:"file_priorities,omitempty"`
+}
+
+// torrentOptionKeys are the status keys holding the torrent options
+var torrentOptionKeys = []string{
+	"max_download_speed", "max_upload_speed", "max_connections", "max_upload_slots",
+	"stop_at_ratio", "stop_ratio", "remove_at_ratio", "auto_managed", "sequential_download",
+	"super_seeding", "prioritize_first_last", "move_completed", "move_completed_path",
+	"file_priorities",
+}
+
+// Bool returns a pointer to v, to set an optional field
+func Bool(v bool) *bool { return &v }
+
+// Int returns a pointer to v, to set an optional field
+func Int(v int) *int { return &v }
+
+// Float returns a pointer to v, to set an optional field
+func Float(v float64) *float64 { return &v }
+
+// String returns a pointer to v, to set an optional field
+func String(v string) *string { return &v }
+
+// SetTorrentOptions applies the set fields of options to the given torrents
+func (d *Deluge) SetTorrentOptions(torrentIds []string, options TorrentOptions) error {
+	return d.call("core.set_torrent_options", []interface{}{torrentIds, options}, nil)
+}
+
+// GetTorrentOptions reads the options of a torrent given its hash id (torrentId)
+func (d *Deluge) GetTorrentOptions(torrentId string) (*TorrentOptions, error) {
+	var status map[string]json.RawMessage
+	err := d.call("core.get_torrent_status", []interface{}{torrentId, torrentOptionKeys}, &status)
+	if err != nil {
+		return nil, err
+	}
+	if len(status) == 0 {
+		return nil, fmt.Errorf("torrent %s not found", torrentId)
+	}
+
+	// the status key differs from the option name
+	if v, found := status["prioritize_first_last"]; found {
+		status["prioritize_first_last_pieces"] = v
+	}
+	raw, _ := json.Marshal(status)
+	var options TorrentOptions
+	if err := json.Unmarshal(raw, &options); err != nil {
+		return nil, fmt.Errorf("unable to parse torrent options. %s", err)
+	}
+	return &options, nil
+}
@@ -0,0 +1,79 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestSettingTorrentOptions(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.set_torrent_options": `{"id": 2, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			err := client.SetTorrentOptions([]string{"asdfgh123456"}, delugeclient.TorrentOptions{
+				MaxDownloadSpeed: delugeclient.Float(-1),
+				StopAtRatio:      delugeclient.Bool(false),
+				StopRatio:        delugeclient.Float(2.5),
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t,
+				`[["asdfgh123456"],{"max_download_speed":-1,"stop_at_ratio":false,"stop_ratio":2.5}]`,
+				requests[1])
+		})
+}
+
+func TestGettingTorrentOptions(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrent_status": `
+		{
+		  "id": 2,
+		  "result": {
+		    "max_download_speed": 512.0,
+		    "max_upload_speed": -1.0,
+		    "max_connections": 120,
+		    "stop_at_ratio": true,
+		    "stop_ratio": 2.0,
+		    "prioritize_first_last": true,
+		    "move_completed_path": "/mnt/done",
+		    "file_priorities": [4, 0, 7]
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			options, err := client.GetTorrentOptions("asdfgh123456")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 512.0, *options.MaxDownloadSpeed)
+			assert.Equal(t, -1.0, *options.MaxUploadSpeed)
+			assert.Equal(t, 120, *options.MaxConnections)
+			assert.Equal(t, true, *options.StopAtRatio)
+			assert.Equal(t, true, *options.PrioritizeFirstLastPieces)
+			assert.Equal(t, "/mnt/done", *options.MoveCompletedPath)
+			assert.Equal(t, (*bool)(nil), options.SuperSeeding)
+			assert.Equal(t, []delugeclient.FilePriority{delugeclient.PriorityNormal, delugeclient.PrioritySkip,
+				delugeclient.PriorityHigh}, options.FilePriorities)
+			assertRequestParams(t, `["asdfgh123456",["max_download_speed","max_upload_speed","max_connections",`+
+				`"max_upload_slots","stop_at_ratio","stop_ratio","remove_at_ratio","auto_managed","sequential_download",`+
+				`"super_seeding","prioritize_first_last","move_completed","move_completed_path","file_priorities"]]`,
+				requests[1])
+		})
+}
@@ -0,0 +1,83 @@
+package delugeclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// Peer is a peer a torrent is connected to. Speeds are in bytes/s and
+// progress goes from 0 to 1
+type Peer struct {
+	Ip        string
+	Port      int
+	Client    string
+	Country   string
+	DownSpeed float64
+	UpSpeed   float64
+	Progress  float64
+	Seed      bool
+}
+
+type peerEntry struct {
+	Ip        string          `json:"ip"`
+	Client    string          `json:"client"`
+	Country   string          `json:"country"`
+	DownSpeed float64         `json:"down_speed"`
+	UpSpeed   float64         `json:"up_speed"`
+	Progress  float64         `json:"progress"`
+	Seed      json.RawMessage `json:"seed"`
+}
+
+// GetPeers gets the peers a torrent is connected to given its hash id (torrentId)
+func (d *Deluge) GetPeers(torrentId string) ([]Peer, error) {
+	var rr struct {
+		Peers []peerEntry `json:"peers"`
+	}
+	err := d.call("core.get_torrent_status", []interface{}{torrentId, []string{"peers"}}, &rr)
+	if err != nil {
+		return nil, err
+	}
+	if rr.Peers == nil {
+		return nil, fmt.Errorf("torrent %s not found", torrentId)
+	}
+
+	peers := make([]Peer, 0, len(rr.Peers))
+	for _, entry := range rr.Peers {
+		peer := Peer{
+			Ip:        entry.Ip,
+			Client:    entry.Client,
+			Country:   entry.Country,
+			DownSpeed: entry.DownSpeed,
+			UpSpeed:   entry.UpSpeed,
+			Progress:  entry.Progress,
+			Seed:      isSet(entry.Seed),
+		}
+		if host, port, err := net.SplitHostPort(entry.Ip); err == nil {
+			peer.Ip = host
+			peer.Port, _ = strconv.Atoi(port)
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
+
+// ConnectPeer makes a torrent connect to the peer at ip and port
+func (d *Deluge) ConnectPeer(torrentId, ip string, port int) error {
+	return d.call("core.connect_peer", []interface{}{torrentId, ip, port}, nil)
+}
+
+// isSet tells whether a flag is on, whether the server sends it as a
+// boolean or as a libtorrent bit mask
+func isSet(flag json.RawMessage) bool {
+	var b bool
+	if err := json.Unmarshal(flag, &b); err == nil {
+		return b
+	}
+	var n float64
+	if err := json.Unmarshal(flag, &n); err == nil {
+		return n != 0
+	}
+	return false
+}
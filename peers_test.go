@@ -0,0 +1,80 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestGettingPeers(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrent_status": `
+		{
+		  "id": 2,
+		  "result": {
+		    "peers": [
+		      {
+		        "client": "qBittorrent 4.6.2",
+		        "country": "DE",
+		        "down_speed": 52428,
+		        "ip": "203.0.113.7:51413",
+		        "progress": 1.0,
+		        "seed": 1024,
+		        "up_speed": 0
+		      },
+		      {
+		        "client": "Transmission 4.0.5",
+		        "country": "  ",
+		        "down_speed": 0,
+		        "ip": "[2001:db8::1]:6881",
+		        "progress": 0.25,
+		        "seed": 0,
+		        "up_speed": 10240
+		      }
+		    ]
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			peers, err := client.GetPeers("asdfgh123456")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(peers))
+			assert.Equal(t, "203.0.113.7", peers[0].Ip)
+			assert.Equal(t, 51413, peers[0].Port)
+			assert.Equal(t, "qBittorrent 4.6.2", peers[0].Client)
+			assert.Equal(t, true, peers[0].Seed)
+			assert.Equal(t, "2001:db8::1", peers[1].Ip)
+			assert.Equal(t, 6881, peers[1].Port)
+			assert.Equal(t, false, peers[1].Seed)
+			assert.Equal(t, 10240.0, peers[1].UpSpeed)
+		})
+}
+
+func TestConnectingPeer(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.connect_peer": `{"id": 2, "result": true, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			if err := client.ConnectPeer("asdfgh123456", "203.0.113.7", 51413); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `["asdfgh123456","203.0.113.7",51413]`, requests[1])
+		})
+}
@@ -0,0 +1,120 @@
+package delugeclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PluginInfo describes a Deluge plugin
+type PluginInfo struct {
+	Name        string `json:"Name"`
+	Version     string `json:"Version"`
+	Author      string `json:"Author"`
+	AuthorEmail string `json:"Author-email"`
+	HomePage    string `json:"Home-page"`
+	License     string `json:"License"`
+	Description string `json:"Description"`
+}
+
+// Plugins are the plugins installed in the server and the ones enabled
+type Plugins struct {
+	Available []string `json:"available_plugins"`
+	Enabled   []string `json:"enabled_plugins"`
+}
+
+// GetAvailablePlugins gets the names of the plugins installed in the daemon
+func (d *Deluge) GetAvailablePlugins() ([]string, error) {
+	var plugins []string
+	err := d.call("core.get_available_plugins", nil, &plugins)
+	return plugins, err
+}
+
+// GetEnabledPlugins gets the names of the plugins enabled in the daemon
+func (d *Deluge) GetEnabledPlugins() ([]string, error) {
+	var plugins []string
+	err := d.call("core.get_enabled_plugins", nil, &plugins)
+	return plugins, err
+}
+
+// EnablePlugin enables a plugin in the daemon given its name
+func (d *Deluge) EnablePlugin(name string) error {
+	var enabled *bool
+	if err := d.call("core.enable_plugin", []interface{}{name}, &enabled); err != nil {
+		return err
+	}
+	if enabled != nil && !*enabled {
+		return fmt.Errorf("plugin %s could not be enabled", name)
+	}
+	return nil
+}
+
+// DisablePlugin disables a plugin in the daemon given its name
+func (d *Deluge) DisablePlugin(name string) error {
+	var disabled *bool
+	if err := d.call("core.disable_plugin", []interface{}{name}, &disabled); err != nil {
+		return err
+	}
+	if disabled != nil && !*disabled {
+		return fmt.Errorf("plugin %s could not be disabled", name)
+	}
+	return nil
+}
+
+// GetPlugins gets the plugins known to the web server
+func (d *Deluge) GetPlugins() (*Plugins, error) {
+	var plugins Plugins
+	if err := d.call("web.get_plugins", nil, &plugins); err != nil {
+		return nil, err
+	}
+	return &plugins, nil
+}
+
+// GetPluginInfo gets the description of a plugin given its name
+func (d *Deluge) GetPluginInfo(name string) (*PluginInfo, error) {
+	var info *PluginInfo
+	if err := d.call("web.get_plugin_info", []interface{}{name}, &info); err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("plugin %s not found", name)
+	}
+	return info, nil
+}
+
+// EnsurePlugins enables the plugins, among the given ones, that are not
+// enabled yet and returns their names. Plugin names are matched ignoring case
+func (d *Deluge) EnsurePlugins(names ...string) ([]string, error) {
+	enabled, err := d.GetEnabledPlugins()
+	if err != nil {
+		return nil, err
+	}
+	available, err := d.GetAvailablePlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	newlyEnabled := make([]string, 0)
+	for _, name := range names {
+		if _, found := findPlugin(enabled, name); found {
+			continue
+		}
+		plugin, found := findPlugin(available, name)
+		if !found {
+			return newlyEnabled, fmt.Errorf("plugin %s is not installed", name)
+		}
+		if err := d.EnablePlugin(plugin); err != nil {
+			return newlyEnabled, err
+		}
+		newlyEnabled = append(newlyEnabled, plugin)
+	}
+	return newlyEnabled, nil
+}
+
+func findPlugin(plugins []string, name string) (string, bool) {
+	for _, plugin := range plugins {
+		if strings.EqualFold(plugin, name) {
+			return plugin, true
+		}
+	}
+	return "", false
+}
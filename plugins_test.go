@@ -0,0 +1,94 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestEnsuringPlugins(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_enabled_plugins":   `{"id": 2, "result": ["Label"], "error": null}`,
+		"core.get_available_plugins": `{"id": 3, "result": ["AutoAdd", "Blocklist", "Label", "Stats"], "error": null}`,
+		"core.enable_plugin":         `{"id": 4, "result": true, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			enabled, err := client.EnsurePlugins("label", "autoadd")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, []string{"AutoAdd"}, enabled)
+			assert.Equal(t, 4, len(requests))
+			assertRequestParams(t, `["AutoAdd"]`, requests[3])
+
+			if _, err := client.EnsurePlugins("Scheduler"); err == nil {
+				t.Fail()
+			}
+		})
+}
+
+func TestEnablingPluginFailing(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.enable_plugin": `{"id": 2, "result": false, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			if err := client.EnablePlugin("Broken"); err == nil {
+				t.Fail()
+			}
+		})
+}
+
+func TestGettingPluginInfo(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.get_plugins": `{"id": 2, "result": {"enabled_plugins": ["Label"], "available_plugins": ["Label", "Stats"]}, "error": null}`,
+		"web.get_plugin_info": `
+		{
+		  "id": 3,
+		  "result": {
+		    "Name": "Label",
+		    "Version": "0.3",
+		    "Author": "Martijn Voncken",
+		    "Author-email": "mvoncken@gmail.com",
+		    "Home-page": "http://deluge-torrent.org",
+		    "License": "GPLv3",
+		    "Description": "Allows labels to be added to torrents."
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			plugins, err := client.GetPlugins()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, []string{"Label", "Stats"}, plugins.Available)
+			assert.Equal(t, []string{"Label"}, plugins.Enabled)
+
+			info, err := client.GetPluginInfo("Label")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, "0.3", info.Version)
+			assert.Equal(t, "mvoncken@gmail.com", info.AuthorEmail)
+		})
+}
@@ -0,0 +1,533 @@
+package delugeclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Condition tells whether a policy rule applies to a torrent at time now
+type Condition func(status *TorrentStatus, now time.Time) bool
+
+// ActionType is what a policy rule does to the torrents it applies to
+type ActionType int
+
+const (
+	ActionPause ActionType = iota
+	ActionRemove
+	ActionRemoveWithData
+	ActionMove
+)
+
+func (a ActionType) String() string {
+	switch a {
+	case ActionPause:
+		return "pause"
+	case ActionRemove:
+		return "remove keep data"
+	case ActionRemoveWithData:
+		return "remove with data"
+	case ActionMove:
+		return "move"
+	}
+	return strconv.Itoa(int(a))
+}
+
+// Action is what a policy rule does, along with the destination of ActionMove.
+// ActionMove only requests the move, which the server carries out in the
+// background; use MoveStorage to wait for it to complete
+type Action struct {
+	Type        ActionType
+	Destination string
+}
+
+func (a Action) String() string {
+	if a.Type == ActionMove {
+		return "move to " + a.Destination
+	}
+	return a.Type.String()
+}
+
+// Rule applies an action to the torrents matching its condition
+type Rule struct {
+	Name      string
+	Condition Condition
+	Action    Action
+}
+
+// Policy is a list of rules. Every torrent gets the action of the first rule it matches
+type Policy struct {
+	Rules []Rule
+}
+
+// PolicyResult is the action a policy decided for a torrent, whether it was
+// applied and the error applying it, if any. A move counts as applied once the
+// server has accepted it, while the data may still be moving
+type PolicyResult struct {
+	Id      string
+	Name    string
+	Rule    string
+	Action  Action
+	Applied bool
+	Err     error
+}
+
+// PolicyReport is the outcome of applying a policy
+type PolicyReport struct {
+	DryRun  bool
+	Results []PolicyResult
+}
+
+// policyFields are the status keys conditions are evaluated on
+var policyFields = []Field{
+	FieldName, FieldState, FieldRatio, FieldSeedingTime, FieldTimeAdded, FieldLabel, FieldTrackerHost,
+}
+
+// RatioAtLeast matches the torrents with a share ratio of at least ratio
+func RatioAtLeast(ratio float64) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		return status.Ratio >= ratio
+	}
+}
+
+// SeedingAtLeast matches the torrents that have been seeding for at least d
+func SeedingAtLeast(d time.Duration) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		return status.SeedingTime >= d
+	}
+}
+
+// AgeAtLeast matches the torrents added at least d ago
+func AgeAtLeast(d time.Duration) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		return torrentAge(status, now) >= d
+	}
+}
+
+// LabelIs matches the torrents with label
+func LabelIs(label string) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		return status.Label == label
+	}
+}
+
+// TrackerIs matches the torrents whose current tracker is at host
+func TrackerIs(host string) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		return strings.EqualFold(status.TrackerHost, host)
+	}
+}
+
+// StateIs matches the torrents in state, such as "Seeding" or "Paused"
+func StateIs(state string) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		return strings.EqualFold(status.State, state)
+	}
+}
+
+// And matches the torrents matching all conditions
+func And(conditions ...Condition) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		for _, condition := range conditions {
+			if !condition(status, now) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches the torrents matching any of the conditions
+func Or(conditions ...Condition) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		for _, condition := range conditions {
+			if condition(status, now) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches the torrents not matching condition
+func Not(condition Condition) Condition {
+	return func(status *TorrentStatus, now time.Time) bool {
+		return !condition(status, now)
+	}
+}
+
+// Pause pauses the given torrents
+func (d *Deluge) Pause(torrentIds []string) error {
+	err := d.call("core.pause_torrents", []interface{}{torrentIds}, nil)
+	if isUnknownMethod(err) {
+		return d.call("core.pause_torrent", []interface{}{torrentIds}, nil)
+	}
+	return err
+}
+
+// ApplyPolicy evaluates policy on every torrent and applies the resulting
+// actions. With dryRun nothing is changed in the server and the report tells
+// what would have been done. Moves are only requested, see Action
+func (d *Deluge) ApplyPolicy(policy Policy, dryRun bool) (*PolicyReport, error) {
+	for _, rule := range policy.Rules {
+		if rule.Condition == nil {
+			return nil, fmt.Errorf("rule '%s' has no condition", rule.Name)
+		}
+	}
+	statuses, err := d.GetStatusesFiltered(Filter{}, policyFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PolicyReport{DryRun: dryRun, Results: make([]PolicyResult, 0)}
+	now := time.Now()
+	for i := range statuses {
+		status := &statuses[i]
+		for _, rule := range policy.Rules {
+			if rule.Condition(status, now) {
+				report.Results = append(report.Results,
+					PolicyResult{Id: status.Id, Name: status.Name, Rule: rule.Name, Action: rule.Action})
+				break
+			}
+		}
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	batches := make(map[Action][]int)
+	var actions []Action
+	for i, result := range report.Results {
+		if _, found := batches[result.Action]; !found {
+			actions = append(actions, result.Action)
+		}
+		batches[result.Action] = append(batches[result.Action], i)
+	}
+	for _, action := range actions {
+		d.applyAction(action, report.Results, batches[action])
+	}
+	return report, nil
+}
+
+func (d *Deluge) applyAction(action Action, results []PolicyResult, batch []int) {
+	ids := make([]string, 0, len(batch))
+	for _, i := range batch {
+		ids = append(ids, results[i].Id)
+	}
+
+	failed := make(map[string]error)
+	switch action.Type {
+	case ActionPause:
+		if err := d.Pause(ids); err != nil {
+			for _, id := range ids {
+				failed[id] = err
+			}
+		}
+	case ActionRemove, ActionRemoveWithData:
+		errs, err := d.RemoveMany(ids, RemoveOptions{DeleteData: action.Type == ActionRemoveWithData})
+		for _, id := range ids {
+			if err != nil {
+				failed[id] = err
+			}
+		}
+		for _, e := range errs {
+			failed[e.Id] = e.Err
+		}
+	case ActionMove:
+		if err := d.call("core.move_storage", []interface{}{ids, action.Destination}, nil); err != nil {
+			for _, id := range ids {
+				failed[id] = err
+			}
+		}
+	}
+
+	for _, i := range batch {
+		results[i].Err = failed[results[i].Id]
+		results[i].Applied = results[i].Err == nil
+	}
+}
+
+func (r *PolicyReport) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		outcome := "done"
+		switch {
+		case r.DryRun:
+			outcome = "dry-run"
+		case result.Err != nil:
+			outcome = "failed: " + result.Err.Error()
+		case result.Action.Type == ActionMove:
+			outcome = "requested"
+		}
+		fmt.Fprintf(&b, "%s %s (%s) by rule '%s': %s\n", result.Action, result.Name, result.Id, result.Rule, outcome)
+	}
+	return b.String()
+}
+
+// ParseRule parses a rule written as "condition -> action", such as
+//
+//	label=tv and ratio>=2 or seeding>14d -> remove keep data
+//
+// Conditions compare ratio, seeding (time), age, label, tracker (host) and
+// state with =, !=, <, <=, > and >=, and combine with and, or, not and
+// parentheses, where and binds tighter than or. Times take the units of
+// time.ParseDuration plus d for days and w for weeks. Actions are pause,
+// remove (keep data), remove with data and move <path>
+func ParseRule(name, rule string) (Rule, error) {
+	parts := strings.SplitN(rule, "->", 2)
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("rule '%s' has no '->' before its action", rule)
+	}
+	condition, err := ParseCondition(parts[0])
+	if err != nil {
+		return Rule{}, err
+	}
+	action, err := parseAction(parts[1])
+	if err != nil {
+		return Rule{}, err
+	}
+	return Rule{Name: name, Condition: condition, Action: action}, nil
+}
+
+// ParseCondition parses a condition as written in ParseRule
+func ParseCondition(expression string) (Condition, error) {
+	p := &conditionParser{tokens: tokenize(expression)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty condition")
+	}
+	condition, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected '%s' in condition '%s'", p.tokens[p.pos], expression)
+	}
+	return condition, nil
+}
+
+func parseAction(s string) (Action, error) {
+	words := strings.Fields(strings.TrimSpace(s))
+	text := strings.ToLower(strings.Join(words, " "))
+	switch text {
+	case "pause":
+		return Action{Type: ActionPause}, nil
+	case "remove", "remove keep data":
+		return Action{Type: ActionRemove}, nil
+	case "remove with data", "remove data":
+		return Action{Type: ActionRemoveWithData}, nil
+	}
+	if len(words) >= 2 && strings.ToLower(words[0]) == "move" {
+		destination := strings.TrimSpace(strings.TrimSpace(s)[len(words[0]):])
+		return Action{Type: ActionMove, Destination: destination}, nil
+	}
+	return Action{}, fmt.Errorf("unknown action '%s'", strings.TrimSpace(s))
+}
+
+type conditionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *conditionParser) or() (Condition, error) {
+	conditions := make([]Condition, 0, 1)
+	for {
+		condition, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+		if !strings.EqualFold(p.peek(), "or") {
+			break
+		}
+		p.next()
+	}
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return Or(conditions...), nil
+}
+
+func (p *conditionParser) and() (Condition, error) {
+	conditions := make([]Condition, 0, 1)
+	for {
+		condition, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+		if !strings.EqualFold(p.peek(), "and") {
+			break
+		}
+		p.next()
+	}
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return And(conditions...), nil
+}
+
+func (p *conditionParser) factor() (Condition, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("condition ends unexpectedly")
+	case strings.EqualFold(token, "not"):
+		condition, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return Not(condition), nil
+	case token == "(":
+		condition, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing ')' in condition")
+		}
+		return condition, nil
+	}
+	return comparison(strings.ToLower(token), p.next(), p.next())
+}
+
+func comparison(field, op, value string) (Condition, error) {
+	if !isOperator(op) {
+		return nil, fmt.Errorf("expected an operator after '%s', got '%s'", field, op)
+	}
+	if value == "" || isOperator(value) || value == "(" || value == ")" {
+		return nil, fmt.Errorf("expected a value after '%s %s'", field, op)
+	}
+
+	switch field {
+	case "ratio":
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ratio '%s'", value)
+		}
+		return func(status *TorrentStatus, now time.Time) bool {
+			return compareNumbers(status.Ratio, op, ratio)
+		}, nil
+	case "seeding", "age":
+		d, err := parsePolicyDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(status *TorrentStatus, now time.Time) bool {
+			elapsed := status.SeedingTime
+			if field == "age" {
+				elapsed = torrentAge(status, now)
+			}
+			return compareNumbers(float64(elapsed), op, float64(d))
+		}, nil
+	case "label", "tracker", "state":
+		if op != "=" && op != "!=" {
+			return nil, fmt.Errorf("%s can only be compared with = or !=", field)
+		}
+		return func(status *TorrentStatus, now time.Time) bool {
+			actual := status.Label
+			switch field {
+			case "tracker":
+				actual = status.TrackerHost
+			case "state":
+				actual = status.State
+			}
+			return strings.EqualFold(actual, value) == (op == "=")
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown field '%s'", field)
+}
+
+func compareNumbers(a float64, op string, b float64) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+func isOperator(token string) bool {
+	switch token {
+	case "=", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func parsePolicyDuration(s string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	if unit, found := units[s[len(s)-1:]]; found {
+		n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time '%s'", s)
+		}
+		return time.Duration(n * float64(unit)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s'", s)
+	}
+	return d, nil
+}
+
+func torrentAge(status *TorrentStatus, now time.Time) time.Duration {
+	if status.TimeAdded.IsZero() {
+		return 0
+	}
+	return now.Sub(status.TimeAdded)
+}
+
+// tokenize splits a condition into parentheses, operators and words
+func tokenize(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case strings.ContainsRune("=!<>", r):
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' {
+				tokens = append(tokens, string(runes[i:i+2]))
+				i += 2
+			} else {
+				tokens = append(tokens, string(r))
+				i++
+			}
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()=!<>", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,168 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"strings"
+	"testing"
+	"time"
+)
+
+const policyStatusResponse = `
+{
+  "id": 2,
+  "result": {
+    "asdfgh123456": {"name": "Some.Show.S01E01", "state": "Seeding", "ratio": 2.1, "seeding_time": 3600, "label": "tv"},
+    "123456asdfgh": {"name": "Some.Linux.Distro", "state": "Seeding", "ratio": 0.4, "seeding_time": 1728000, "label": ""},
+    "zxcvbn987654": {"name": "Some.Video", "state": "Downloading", "ratio": 0.0, "seeding_time": 0, "label": "movies"}
+  },
+  "error": null
+}`
+
+func TestParsingRules(t *testing.T) {
+	now := time.Now()
+	rule, err := delugeclient.ParseRule("cleanup", "label=tv and ratio>=2 or seeding>14d -> remove keep data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, delugeclient.Action{Type: delugeclient.ActionRemove}, rule.Action)
+	assert.Equal(t, true, rule.Condition(&delugeclient.TorrentStatus{Label: "tv", Ratio: 2}, now))
+	assert.Equal(t, false, rule.Condition(&delugeclient.TorrentStatus{Label: "tv", Ratio: 1.9}, now))
+	assert.Equal(t, true, rule.Condition(&delugeclient.TorrentStatus{SeedingTime: 15 * 24 * time.Hour}, now))
+
+	rule, err = delugeclient.ParseRule("old", "not (state = Downloading) and age >= 2w -> move /mnt/archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, delugeclient.Action{Type: delugeclient.ActionMove, Destination: "/mnt/archive"}, rule.Action)
+	added := now.Add(-15 * 24 * time.Hour)
+	assert.Equal(t, true, rule.Condition(&delugeclient.TorrentStatus{State: "Seeding", TimeAdded: added}, now))
+	assert.Equal(t, false, rule.Condition(&delugeclient.TorrentStatus{State: "downloading", TimeAdded: added}, now))
+
+	for _, invalid := range []string{
+		"ratio>=2",
+		"ratio>=two -> pause",
+		"label>tv -> pause",
+		"color=red -> pause",
+		"(ratio>=2 -> pause",
+		"ratio>=2 -> explode",
+	} {
+		if _, err := delugeclient.ParseRule("invalid", invalid); err == nil {
+			t.Errorf("'%s' was not rejected", invalid)
+		}
+	}
+}
+
+func TestApplyingPolicyDryRun(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": policyStatusResponse,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			policy := delugeclient.Policy{Rules: []delugeclient.Rule{
+				{
+					Name:      "tv",
+					Condition: delugeclient.And(delugeclient.LabelIs("tv"), delugeclient.RatioAtLeast(2)),
+					Action:    delugeclient.Action{Type: delugeclient.ActionRemove},
+				},
+				{
+					Name:      "long seeding",
+					Condition: delugeclient.SeedingAtLeast(14 * 24 * time.Hour),
+					Action:    delugeclient.Action{Type: delugeclient.ActionPause},
+				},
+			}}
+			report, err := client.ApplyPolicy(policy, true)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			fmt.Print(report)
+			assert.Equal(t, 2, len(requests))
+			assert.Equal(t, 2, len(report.Results))
+			assert.Equal(t, "Some.Linux.Distro", report.Results[0].Name)
+			assert.Equal(t, "long seeding", report.Results[0].Rule)
+			assert.Equal(t, "asdfgh123456", report.Results[1].Id)
+			assert.Equal(t, false, report.Results[1].Applied)
+			assert.Equal(t, true, strings.Contains(report.String(),
+				"remove keep data Some.Show.S01E01 (asdfgh123456) by rule 'tv': dry-run"))
+		})
+}
+
+func TestApplyingPolicy(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": policyStatusResponse,
+		"core.remove_torrents":     `{"id": 3, "result": [], "error": null}`,
+		"core.pause_torrent":       `{"id": 4, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			remove, _ := delugeclient.ParseRule("tv", "label=tv and ratio>=2 -> remove with data")
+			pause, _ := delugeclient.ParseRule("long seeding", "seeding>=14d -> pause")
+			report, err := client.ApplyPolicy(delugeclient.Policy{Rules: []delugeclient.Rule{remove, pause}}, false)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(report.Results))
+			assert.Equal(t, true, report.Results[0].Applied)
+			assert.Equal(t, true, report.Results[1].Applied)
+			assertRequestParams(t, `[["123456asdfgh"]]`, requests[2])
+			assertRequestParams(t, `[["123456asdfgh"]]`, requests[3])
+			assertRequestParams(t, `[["asdfgh123456"],true]`, requests[4])
+		})
+}
+
+func TestApplyingPolicyWithMove(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": policyStatusResponse,
+		"core.move_storage":        `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			move, _ := delugeclient.ParseRule("movies", "label=movies -> move /mnt/movies")
+			report, err := client.ApplyPolicy(delugeclient.Policy{Rules: []delugeclient.Rule{move}}, false)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 1, len(report.Results))
+			assert.Equal(t, true, report.Results[0].Applied)
+			assertRequestParams(t, `[["zxcvbn987654"],"/mnt/movies"]`, requests[2])
+			assert.Equal(t, true, strings.Contains(report.String(),
+				"move to /mnt/movies Some.Video (zxcvbn987654) by rule 'movies': requested"))
+		})
+}
+
+func TestApplyingPolicyWithoutCondition(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": policyStatusResponse,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			policy := delugeclient.Policy{Rules: []delugeclient.Rule{
+				{Name: "everything", Action: delugeclient.Action{Type: delugeclient.ActionPause}},
+			}}
+			if _, err := client.ApplyPolicy(policy, false); err == nil {
+				t.Fail()
+			}
+			assert.Equal(t, 1, len(requests))
+		})
+}
@@ -0,0 +1,138 @@
+package delugeclient
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// FilePriority is the download priority of a file inside a torrent
+type FilePriority int
+
+const (
+	PrioritySkip   FilePriority = 0
+	PriorityLow    FilePriority = 1
+	PriorityNormal FilePriority = 4
+	PriorityHigh   FilePriority = 7
+)
+
+// TorrentFile is a file inside a torrent along with its download priority
+type TorrentFile struct {
+	Index    int          `json:"index"`
+	Path     string       `json:"path"`
+	Size     int64        `json:"size"`
+	Offset   int64        `json:"offset"`
+	Priority FilePriority `json:"-"`
+}
+
+// FileSelector tells whether a file should be downloaded
+type FileSelector func(file TorrentFile) bool
+
+// MatchGlob selects the files whose path, or name, matches the shell pattern
+func MatchGlob(pattern string) FileSelector {
+	return func(file TorrentFile) bool {
+		if matched, _ := path.Match(pattern, file.Path); matched {
+			return true
+		}
+		matched, _ := path.Match(pattern, path.Base(file.Path))
+		return matched
+	}
+}
+
+// MatchExtension selects the files with any of the extensions, ignoring case
+func MatchExtension(extensions ...string) FileSelector {
+	return func(file TorrentFile) bool {
+		ext := strings.ToLower(path.Ext(file.Path))
+		for _, e := range extensions {
+			if ext == "."+strings.ToLower(strings.TrimPrefix(e, ".")) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// LargerThan selects the files bigger than size bytes
+func LargerThan(size int64) FileSelector {
+	return func(file TorrentFile) bool {
+		return file.Size > size
+	}
+}
+
+// AnyOf selects the files matching at least one of the selectors
+func AnyOf(selectors ...FileSelector) FileSelector {
+	return func(file TorrentFile) bool {
+		for _, selector := range selectors {
+			if selector(file) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllOf selects the files matching every one of the selectors
+func AllOf(selectors ...FileSelector) FileSelector {
+	return func(file TorrentFile) bool {
+		for _, selector := range selectors {
+			if !selector(file) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Priorities returns the priority of every file, by index, with the selected
+// files at PriorityNormal and the rest skipped
+func Priorities(files []TorrentFile, selector FileSelector) []FilePriority {
+	priorities := make([]FilePriority, len(files))
+	for _, file := range files {
+		if file.Index < 0 || file.Index >= len(priorities) {
+			continue
+		}
+		if selector(file) {
+			priorities[file.Index] = PriorityNormal
+		} else {
+			priorities[file.Index] = PrioritySkip
+		}
+	}
+	return priorities
+}
+
+// GetFiles gets the files of a torrent, ordered by index, with their priorities
+func (d *Deluge) GetFiles(torrentId string) ([]TorrentFile, error) {
+	var rr struct {
+		Files          []TorrentFile  `json:"files"`
+		FilePriorities []FilePriority `json:"file_priorities"`
+	}
+	err := d.call("core.get_torrent_status",
+		[]interface{}{torrentId, []string{"files", "file_priorities"}}, &rr)
+	if err != nil {
+		return nil, err
+	}
+	if rr.Files == nil {
+		return nil, fmt.Errorf("torrent %s not found", torrentId)
+	}
+
+	for i := range rr.Files {
+		if index := rr.Files[i].Index; index >= 0 && index < len(rr.FilePriorities) {
+			rr.Files[i].Priority = rr.FilePriorities[index]
+		}
+	}
+	return rr.Files, nil
+}
+
+// SetFilePriorities sets the priority of every file of a torrent, by index
+func (d *Deluge) SetFilePriorities(torrentId string, priorities []FilePriority) error {
+	return d.SetTorrentOptions([]string{torrentId}, TorrentOptions{FilePriorities: priorities})
+}
+
+// SelectFiles downloads only the files of a torrent matching the selector
+func (d *Deluge) SelectFiles(torrentId string, selector FileSelector) error {
+	files, err := d.GetFiles(torrentId)
+	if err != nil {
+		return err
+	}
+	return d.SetFilePriorities(torrentId, Priorities(files, selector))
+}
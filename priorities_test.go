@@ -0,0 +1,90 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestSelectingFilePriorities(t *testing.T) {
+	files := []delugeclient.TorrentFile{
+		{Index: 0, Path: "Show.S01/Show.S01E01.mkv", Size: 1500000000},
+		{Index: 1, Path: "Show.S01/Sample/sample.mkv", Size: 20000000},
+		{Index: 2, Path: "Show.S01/Show.S01E01.srt", Size: 40000},
+		{Index: 3, Path: "Show.S01/info.nfo", Size: 300},
+	}
+	priorities := delugeclient.Priorities(files, delugeclient.AnyOf(
+		delugeclient.AllOf(delugeclient.MatchExtension("MKV"), delugeclient.LargerThan(100000000)),
+		delugeclient.MatchGlob("*.srt"),
+	))
+	assert.Equal(t, []delugeclient.FilePriority{
+		delugeclient.PriorityNormal,
+		delugeclient.PrioritySkip,
+		delugeclient.PriorityNormal,
+		delugeclient.PrioritySkip,
+	}, priorities)
+}
+
+func TestSelectingFiles(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrent_status": `
+		{
+		  "id": 2,
+		  "result": {
+		    "files": [
+		      {"index": 0, "path": "Some.Linux.Distro/Distribution.iso", "size": 1019968456, "offset": 0},
+		      {"index": 1, "path": "Some.Linux.Distro/README.txt", "size": 30, "offset": 1019968456}
+		    ],
+		    "file_priorities": [4, 4]
+		  },
+		  "error": null
+		}`,
+		"core.set_torrent_options": `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			files, err := client.GetFiles("asdfgh123456")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(files))
+			assert.Equal(t, "Some.Linux.Distro/README.txt", files[1].Path)
+			assert.Equal(t, delugeclient.PriorityNormal, files[1].Priority)
+
+			if err := client.SelectFiles("asdfgh123456", delugeclient.MatchExtension("iso")); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[["asdfgh123456"],{"file_priorities":[4,0]}]`, requests[3])
+		})
+}
+
+func TestAddingMagnetWithFilePriorities(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.add_torrents": `{"id": 2, "result": [[true, "asdfgh123456"]], "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			err := client.AddMagnetWithOptions("magnet:?xt=urn:btih:asdfgh123456", delugeclient.TorrentOptions{
+				FilePriorities: []delugeclient.FilePriority{delugeclient.PriorityHigh, delugeclient.PrioritySkip},
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t,
+				`[[{"options":{"file_priorities":[7,0]},"path":"magnet:?xt=urn:btih:asdfgh123456"}]]`,
+				requests[1])
+		})
+}
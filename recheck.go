@@ -0,0 +1,103 @@
+package delugeclient
+
+import (
+	"context"
+	"time"
+)
+
+// RecheckResult is the outcome of a finished recheck of a single torrent
+type RecheckResult struct {
+	Id       string
+	State    string
+	Progress float64
+}
+
+// ForceRecheck makes the server verify the downloaded data of the given torrents
+func (d *Deluge) ForceRecheck(torrentIds []string) error {
+	return d.call("core.force_recheck", []interface{}{torrentIds}, nil)
+}
+
+// ForceReannounce makes the server announce the given torrents to their trackers
+func (d *Deluge) ForceReannounce(torrentIds []string) error {
+	return d.call("core.force_reannounce", []interface{}{torrentIds}, nil)
+}
+
+// recheckStartPolls is how many polls a torrent gets to show the recheck
+// started, by checking or changing its progress, before its current state is
+// taken as the result. Small torrents may be rechecked between two polls
+const recheckStartPolls = 10
+
+type recheckStatus struct {
+	State    string  `json:"state"`
+	Progress float64 `json:"progress"`
+}
+
+// recheckTracker follows a torrent from before its recheck until it is done
+type recheckTracker struct {
+	initialProgress float64
+	seenChecking    bool
+	done            bool
+}
+
+// ForceRecheckAndWait rechecks the given torrents and polls their state every
+// interval until each of them has finished checking, returning their progress.
+// A torrent is finished once it has been seen checking, or its progress has
+// changed, and it is not checking anymore
+func (d *Deluge) ForceRecheckAndWait(ctx context.Context, torrentIds []string, interval time.Duration) ([]RecheckResult, error) {
+	initial, err := d.recheckStatuses(torrentIds)
+	if err != nil {
+		return nil, err
+	}
+	trackers := make(map[string]*recheckTracker, len(initial))
+	for id, status := range initial {
+		trackers[id] = &recheckTracker{initialProgress: status.Progress}
+	}
+	if err := d.ForceRecheck(torrentIds); err != nil {
+		return nil, err
+	}
+
+	for polls := 1; ; polls++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		statuses, err := d.recheckStatuses(torrentIds)
+		if err != nil {
+			return nil, err
+		}
+		pending := false
+		results := make([]RecheckResult, 0, len(torrentIds))
+		for _, id := range torrentIds {
+			status, found := statuses[id]
+			tracker, tracked := trackers[id]
+			if !found || !tracked {
+				continue
+			}
+			tracker.update(status, polls)
+			if !tracker.done {
+				pending = true
+			}
+			results = append(results, RecheckResult{Id: id, State: status.State, Progress: status.Progress})
+		}
+		if !pending {
+			return results, nil
+		}
+	}
+}
+
+func (t *recheckTracker) update(status recheckStatus, polls int) {
+	if status.State == "Checking" || status.State == "Allocating" {
+		t.seenChecking = true
+		t.done = false
+		return
+	}
+	t.done = t.seenChecking || status.Progress != t.initialProgress || polls >= recheckStartPolls
+}
+
+func (d *Deluge) recheckStatuses(torrentIds []string) (map[string]recheckStatus, error) {
+	var rr map[string]recheckStatus
+	err := d.torrentsStatus(torrentIds, []string{"state", "progress"}, &rr)
+	return rr, err
+}
@@ -0,0 +1,118 @@
+package delugeclient_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestForcingReannounce(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.force_reannounce": `{"id": 2, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			if err := client.ForceReannounce([]string{"asdfgh123456", "123456asdfgh"}); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[["asdfgh123456","123456asdfgh"]]`, requests[1])
+		})
+}
+
+func TestForcingRecheckAndWaiting(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.force_recheck": `{"id": 2, "result": null, "error": null}`,
+		"core.get_torrents_status": `
+		{
+		  "id": 3,
+		  "result": {
+		    "asdfgh123456": {"state": "Seeding", "progress": 100.0},
+		    "123456asdfgh": {"state": "Paused", "progress": 42.5}
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			results, err := client.ForceRecheckAndWait(context.Background(),
+				[]string{"asdfgh123456", "123456asdfgh"}, time.Millisecond)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"id":["asdfgh123456","123456asdfgh"]},["state","progress"]]`, requests[1])
+			assertRequestParams(t, `[["asdfgh123456","123456asdfgh"]]`, requests[2])
+			assert.Equal(t, 2, len(results))
+			assert.Equal(t, "Seeding", results[0].State)
+			assert.Equal(t, 100.0, results[0].Progress)
+			assert.Equal(t, "123456asdfgh", results[1].Id)
+			assert.Equal(t, 42.5, results[1].Progress)
+		})
+}
+
+func TestForcingRecheckAndWaitingUntilStarted(t *testing.T) {
+	responses := []string{
+		`{"id": 2, "result": {"asdfgh123456": {"state": "Seeding", "progress": 100.0}}, "error": null}`,
+		`{"id": 3, "result": null, "error": null}`,
+		`{"id": 4, "result": {"asdfgh123456": {"state": "Seeding", "progress": 100.0}}, "error": null}`,
+		`{"id": 5, "result": {"asdfgh123456": {"state": "Checking", "progress": 40.0}}, "error": null}`,
+		`{"id": 6, "result": {"asdfgh123456": {"state": "Seeding", "progress": 99.5}}, "error": null}`,
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(200)
+		if len(responses) == 0 {
+			io.WriteString(w, `{"id": 1, "result": true, "error": null}`)
+			return
+		}
+		io.WriteString(w, responses[0])
+		responses = responses[1:]
+	})
+
+	testflight.WithServer(handler, func(r *testflight.Requester) {
+		client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+		results, err := client.ForceRecheckAndWait(context.Background(), []string{"asdfgh123456"}, time.Millisecond)
+		if err != nil {
+			fmt.Println(err)
+			t.Fail()
+		}
+		assert.Equal(t, 0, len(responses))
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, "Seeding", results[0].State)
+		assert.Equal(t, 99.5, results[0].Progress)
+	})
+}
+
+func TestForcingRecheckAndWaitingCancelled(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.force_recheck": `{"id": 2, "result": null, "error": null}`,
+		"core.get_torrents_status": `
+		{"id": 3, "result": {"asdfgh123456": {"state": "Checking", "progress": 10.0}}, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			if _, err := client.ForceRecheckAndWait(ctx, []string{"asdfgh123456"}, time.Millisecond); err == nil {
+				t.Fail()
+			}
+		})
+}
@@ -0,0 +1,89 @@
+package delugeclient
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// RenameFiles renames files of a torrent, given as file index to new path
+// relative to the torrent root. Indexes and paths are checked against the
+// current file tree of the torrent before anything is sent to the server
+func (d *Deluge) RenameFiles(torrentId string, files map[int]string) error {
+	tree, err := d.GetFileTree(torrentId)
+	if err != nil {
+		return err
+	}
+	existing := make(map[int]bool)
+	for _, file := range tree.Files() {
+		existing[file.Index] = true
+	}
+
+	indexes := make([]int, 0, len(files))
+	for index := range files {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	renames := make([][]interface{}, 0, len(files))
+	targets := make(map[string]int)
+	for _, index := range indexes {
+		if !existing[index] {
+			return fmt.Errorf("torrent %s has no file with index %d", torrentId, index)
+		}
+		newPath, err := cleanTorrentPath(files[index])
+		if err != nil {
+			return err
+		}
+		if other, found := targets[newPath]; found {
+			return fmt.Errorf("files %d and %d cannot both be renamed to '%s'", other, index, newPath)
+		}
+		targets[newPath] = index
+		renames = append(renames, []interface{}{index, newPath})
+	}
+	return d.call("core.rename_files", []interface{}{torrentId, renames}, nil)
+}
+
+// RenameFolder renames a folder of a torrent, both given as paths relative to
+// the torrent root. The folder must exist in the current file tree of the torrent
+func (d *Deluge) RenameFolder(torrentId, oldFolder, newFolder string) error {
+	tree, err := d.GetFileTree(torrentId)
+	if err != nil {
+		return err
+	}
+	oldPath, err := cleanTorrentPath(oldFolder)
+	if err != nil {
+		return err
+	}
+	newPath, err := cleanTorrentPath(newFolder)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	tree.Walk(func(node *FileNode) error {
+		if node.Dir && node.Path == oldPath {
+			found = true
+		}
+		return nil
+	})
+	if !found {
+		return fmt.Errorf("torrent %s has no folder '%s'", torrentId, oldPath)
+	}
+	return d.call("core.rename_folder", []interface{}{torrentId, oldPath + "/", newPath + "/"}, nil)
+}
+
+// cleanTorrentPath normalizes a path inside a torrent, rejecting the ones that
+// are empty, absolute or escape the torrent root
+func cleanTorrentPath(p string) (string, error) {
+	slashed := strings.ReplaceAll(p, `\`, "/")
+	if strings.HasPrefix(slashed, "/") || (len(slashed) > 1 && slashed[1] == ':') {
+		return "", fmt.Errorf("path '%s' must be relative to the torrent root", p)
+	}
+	cleaned := path.Clean(slashed)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("path '%s' is outside the torrent root", p)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,101 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+const torrentFilesResponse = `
+{
+  "id": 2,
+  "result": {
+    "type": "dir",
+    "contents": {
+      "Some.Linux.Distro": {
+        "path": "Some.Linux.Distro",
+        "type": "dir",
+        "contents": {
+          "README.txt": {"index": 1, "path": "Some.Linux.Distro/README.txt", "type": "file"},
+          "Distribution.iso": {"index": 0, "path": "Some.Linux.Distro/Distribution.iso", "type": "file"}
+        }
+      }
+    }
+  },
+  "error": null
+}`
+
+func TestRenamingFiles(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.get_torrent_files": torrentFilesResponse,
+		"core.rename_files":     `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			err := client.RenameFiles("asdfgh123456", map[int]string{
+				1: "Some.Linux.Distro/readme.txt",
+				0: "Some.Linux.Distro/./distro.iso",
+			})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t,
+				`["asdfgh123456",[[0,"Some.Linux.Distro/distro.iso"],[1,"Some.Linux.Distro/readme.txt"]]]`,
+				requests[2])
+		})
+}
+
+func TestRenamingFilesRejected(t *testing.T) {
+	for _, files := range []map[int]string{
+		{5: "Some.Linux.Distro/other.txt"},
+		{0: "../escape.iso"},
+		{0: "/etc/passwd"},
+		{0: "same.txt", 1: "same.txt"},
+	} {
+		var requests []string
+		testflight.WithServer(MethodHandler(map[string]string{
+			"web.get_torrent_files": torrentFilesResponse,
+			"core.rename_files":     `{"id": 3, "result": null, "error": null}`,
+		}, &requests),
+			func(r *testflight.Requester) {
+				client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+				if err := client.Connect(); err != nil {
+					t.Fail()
+				}
+				if err := client.RenameFiles("asdfgh123456", files); err == nil {
+					t.Errorf("%v was not rejected", files)
+				}
+				if len(requests) != 2 {
+					t.Errorf("%v was sent to the server", files)
+				}
+			})
+	}
+}
+
+func TestRenamingFolder(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"web.get_torrent_files": torrentFilesResponse,
+		"core.rename_folder":    `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			if err := client.RenameFolder("asdfgh123456", "Some.Linux.Distro/", "Linux Distro"); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `["asdfgh123456","Some.Linux.Distro/","Linux Distro/"]`, requests[2])
+			if err := client.RenameFolder("asdfgh123456", "Missing", "Linux Distro"); err == nil {
+				t.Fail()
+			}
+		})
+}
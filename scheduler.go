@@ -0,0 +1,166 @@
+package delugeclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ScheduleState is what the Scheduler plugin does during an hour of the week
+type ScheduleState int
+
+const (
+	// Green applies no limits
+	Green ScheduleState = iota
+	// Yellow applies the limits of the SchedulerConfig
+	Yellow
+	// Red pauses all torrents
+	Red
+)
+
+// Weekdays are Monday to Friday
+var Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// Weekend are Saturday and Sunday
+var Weekend = []time.Weekday{time.Saturday, time.Sunday}
+
+// WeeklySchedule is the state of each hour of the week
+type WeeklySchedule struct {
+	// slots are indexed by day, starting on Monday as Deluge does, and hour
+	slots [7][24]ScheduleState
+}
+
+// SchedulerConfig is the configuration of the Scheduler plugin. The limits
+// apply in the Yellow hours, with speeds in KiB/s and -1 meaning unlimited
+type SchedulerConfig struct {
+	Schedule             WeeklySchedule `json:"button_state"`
+	LowDownloadSpeed     float64        `json:"low_down"`
+	LowUploadSpeed       float64        `json:"low_up"`
+	LowActive            int            `json:"low_active"`
+	LowActiveDownloading int            `json:"low_active_down"`
+	LowActiveSeeding     int            `json:"low_active_up"`
+}
+
+// SchedulerClient manages the bandwidth schedule through the Scheduler plugin, which has to be enabled
+type SchedulerClient struct {
+	deluge *Deluge
+}
+
+// NewWeeklySchedule returns a schedule with every hour in state
+func NewWeeklySchedule(state ScheduleState) WeeklySchedule {
+	var schedule WeeklySchedule
+	for day := range schedule.slots {
+		for hour := range schedule.slots[day] {
+			schedule.slots[day][hour] = state
+		}
+	}
+	return schedule
+}
+
+// At returns the state of an hour, from 0 to 23, of day. It panics when hour
+// is out of that range
+func (s *WeeklySchedule) At(day time.Weekday, hour int) ScheduleState {
+	checkHour(hour)
+	return s.slots[dayIndex(day)][hour]
+}
+
+// Set changes the state of an hour, from 0 to 23, of day. It panics when hour
+// is out of that range
+func (s *WeeklySchedule) Set(day time.Weekday, hour int, state ScheduleState) {
+	checkHour(hour)
+	s.slots[dayIndex(day)][hour] = state
+}
+
+// SetRange changes the state of the hours from the hour from, 0 to 23, until
+// the hour to, 0 to 24 and not included, of each of days. A to of 24 is
+// midnight at the end of the day. Ranges where from is after to go past
+// midnight, into the next day
+func (s *WeeklySchedule) SetRange(days []time.Weekday, from, to int, state ScheduleState) error {
+	if from < 0 || from > 23 {
+		return fmt.Errorf("invalid schedule hour %d, expected 0 to 23", from)
+	}
+	if to < 0 || to > 24 {
+		return fmt.Errorf("invalid schedule hour %d, expected 0 to 24", to)
+	}
+	hours := (to - from + 24) % 24
+	if to == 24 {
+		hours = 24 - from
+	}
+	for _, day := range days {
+		for hour := from; hour < from+hours; hour++ {
+			if hour > 23 {
+				s.Set(day+1, hour-24, state)
+			} else {
+				s.Set(day, hour, state)
+			}
+		}
+	}
+	return nil
+}
+
+func checkHour(hour int) {
+	if hour < 0 || hour > 23 {
+		panic(fmt.Sprintf("invalid schedule hour %d, expected 0 to 23", hour))
+	}
+}
+
+// MarshalJSON encodes the schedule as the plugin does, by hour and then by day
+func (s WeeklySchedule) MarshalJSON() ([]byte, error) {
+	var grid [24][7]ScheduleState
+	for day := range s.slots {
+		for hour := range s.slots[day] {
+			grid[hour][day] = s.slots[day][hour]
+		}
+	}
+	return json.Marshal(grid)
+}
+
+// UnmarshalJSON decodes the schedule as the plugin sends it, by hour and then by day
+func (s *WeeklySchedule) UnmarshalJSON(data []byte) error {
+	var grid [][]ScheduleState
+	if err := json.Unmarshal(data, &grid); err != nil {
+		return err
+	}
+	if len(grid) != 24 {
+		return fmt.Errorf("schedule has %d hours", len(grid))
+	}
+	for hour := range grid {
+		if len(grid[hour]) != 7 {
+			return fmt.Errorf("schedule hour %d has %d days", hour, len(grid[hour]))
+		}
+		for day := range grid[hour] {
+			s.slots[day][hour] = grid[hour][day]
+		}
+	}
+	return nil
+}
+
+// Scheduler returns the client of the Scheduler plugin
+func (d *Deluge) Scheduler() *SchedulerClient {
+	return &SchedulerClient{deluge: d}
+}
+
+// GetConfig gets the schedule and the limits of the Yellow hours
+func (c *SchedulerClient) GetConfig() (*SchedulerConfig, error) {
+	var config SchedulerConfig
+	if err := c.deluge.call("scheduler.get_config", nil, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SetConfig replaces the schedule and the limits of the Yellow hours
+func (c *SchedulerClient) SetConfig(config SchedulerConfig) error {
+	return c.deluge.call("scheduler.set_config", []interface{}{config}, nil)
+}
+
+// GetState gets the state the daemon is in right now: "Green", "Yellow" or "Red"
+func (c *SchedulerClient) GetState() (string, error) {
+	var state string
+	err := c.deluge.call("scheduler.get_state", nil, &state)
+	return state, err
+}
+
+func dayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
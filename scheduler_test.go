@@ -0,0 +1,125 @@
+package delugeclient_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildingWeeklySchedule(t *testing.T) {
+	schedule := delugeclient.NewWeeklySchedule(delugeclient.Green)
+	if err := schedule.SetRange(delugeclient.Weekdays, 9, 18, delugeclient.Yellow); err != nil {
+		t.Fail()
+	}
+	if err := schedule.SetRange([]time.Weekday{time.Sunday}, 22, 2, delugeclient.Red); err != nil {
+		t.Fail()
+	}
+
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Monday, 8))
+	assert.Equal(t, delugeclient.Yellow, schedule.At(time.Monday, 9))
+	assert.Equal(t, delugeclient.Yellow, schedule.At(time.Friday, 17))
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Friday, 18))
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Saturday, 12))
+	assert.Equal(t, delugeclient.Red, schedule.At(time.Sunday, 23))
+	assert.Equal(t, delugeclient.Red, schedule.At(time.Monday, 1))
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Monday, 2))
+}
+
+func TestBuildingWeeklyScheduleUntilMidnight(t *testing.T) {
+	schedule := delugeclient.NewWeeklySchedule(delugeclient.Green)
+	if err := schedule.SetRange(delugeclient.Weekend, 20, 24, delugeclient.Yellow); err != nil {
+		t.Fail()
+	}
+	if err := schedule.SetRange([]time.Weekday{time.Wednesday}, 0, 24, delugeclient.Red); err != nil {
+		t.Fail()
+	}
+
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Saturday, 19))
+	assert.Equal(t, delugeclient.Yellow, schedule.At(time.Saturday, 20))
+	assert.Equal(t, delugeclient.Yellow, schedule.At(time.Sunday, 23))
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Monday, 0))
+	assert.Equal(t, delugeclient.Red, schedule.At(time.Wednesday, 0))
+	assert.Equal(t, delugeclient.Red, schedule.At(time.Wednesday, 23))
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Thursday, 0))
+}
+
+func TestBuildingWeeklyScheduleWithInvalidHours(t *testing.T) {
+	schedule := delugeclient.NewWeeklySchedule(delugeclient.Green)
+	if err := schedule.SetRange(delugeclient.Weekdays, 9, 25, delugeclient.Red); err == nil {
+		t.Fail()
+	}
+	if err := schedule.SetRange(delugeclient.Weekdays, 24, 2, delugeclient.Red); err == nil {
+		t.Fail()
+	}
+	if err := schedule.SetRange(delugeclient.Weekdays, -1, 2, delugeclient.Red); err == nil {
+		t.Fail()
+	}
+	assert.Equal(t, delugeclient.Green, schedule.At(time.Monday, 9))
+	assertPanic(t, func() {
+		schedule.At(time.Monday, -1)
+	})
+	assertPanic(t, func() {
+		schedule.Set(time.Monday, 24, delugeclient.Red)
+	})
+}
+
+func TestGettingSchedulerConfig(t *testing.T) {
+	hours := make([]string, 24)
+	for hour := range hours {
+		hours[hour] = "[0,0,0,0,0,0,0]"
+	}
+	hours[10] = "[1,1,1,1,1,0,2]"
+
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"scheduler.get_config": `
+		{
+		  "id": 2,
+		  "result": {
+		    "button_state": [` + strings.Join(hours, ",") + `],
+		    "low_down": 250.0,
+		    "low_up": 50.0,
+		    "low_active": 4,
+		    "low_active_down": 2,
+		    "low_active_up": 2
+		  },
+		  "error": null
+		}`,
+		"scheduler.set_config": `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			config, err := client.Scheduler().GetConfig()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 250.0, config.LowDownloadSpeed)
+			assert.Equal(t, 2, config.LowActiveSeeding)
+			assert.Equal(t, delugeclient.Yellow, config.Schedule.At(time.Monday, 10))
+			assert.Equal(t, delugeclient.Green, config.Schedule.At(time.Saturday, 10))
+			assert.Equal(t, delugeclient.Red, config.Schedule.At(time.Sunday, 10))
+			assert.Equal(t, delugeclient.Green, config.Schedule.At(time.Sunday, 11))
+
+			if err := client.Scheduler().SetConfig(*config); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			var sent struct {
+				Params []struct {
+					ButtonState [][]int `json:"button_state"`
+				} `json:"params"`
+			}
+			json.Unmarshal([]byte(requests[2]), &sent)
+			assert.Equal(t, 24, len(sent.Params[0].ButtonState))
+			assert.Equal(t, []int{1, 1, 1, 1, 1, 0, 2}, sent.Params[0].ButtonState[10])
+		})
+}
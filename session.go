@@ -0,0 +1,104 @@
+package delugeclient
+
+// SessionStatus is the health of the daemon at a point in time. Rates are in
+// bytes/s and disk space in bytes
+type SessionStatus struct {
+	PayloadDownloadRate    float64
+	PayloadUploadRate      float64
+	DownloadRate           float64
+	UploadRate             float64
+	DhtNodes               int
+	NumPeers               int
+	NumConnections         int
+	HasIncomingConnections bool
+	MaxNumConnections      int
+	FreeSpace              int64
+	DownloadLocation       string
+	ListenPort             int
+	ExternalIp             string
+}
+
+var sessionStatusKeys = []string{
+	"payload_download_rate", "payload_upload_rate", "download_rate", "upload_rate",
+	"dht_nodes", "num_peers", "has_incoming_connections",
+}
+
+// GetSessionStatus gets the transfer rates, connections, free space in the
+// download location and network details of the daemon in a single snapshot.
+// The size of the download location is left out as measuring it walks the
+// whole directory, use GetPathSize for that
+func (d *Deluge) GetSessionStatus() (*SessionStatus, error) {
+	var session struct {
+		PayloadDownloadRate    float64 `json:"payload_download_rate"`
+		PayloadUploadRate      float64 `json:"payload_upload_rate"`
+		DownloadRate           float64 `json:"download_rate"`
+		UploadRate             float64 `json:"upload_rate"`
+		DhtNodes               int     `json:"dht_nodes"`
+		NumPeers               int     `json:"num_peers"`
+		HasIncomingConnections bool    `json:"has_incoming_connections"`
+	}
+	if err := d.call("core.get_session_status", []interface{}{sessionStatusKeys}, &session); err != nil {
+		return nil, err
+	}
+	status := &SessionStatus{
+		PayloadDownloadRate:    session.PayloadDownloadRate,
+		PayloadUploadRate:      session.PayloadUploadRate,
+		DownloadRate:           session.DownloadRate,
+		UploadRate:             session.UploadRate,
+		DhtNodes:               session.DhtNodes,
+		NumPeers:               session.NumPeers,
+		HasIncomingConnections: session.HasIncomingConnections,
+		NumConnections:         session.NumPeers,
+	}
+	var err error
+	if status.ListenPort, err = d.GetListenPort(); err != nil {
+		return nil, err
+	}
+	if status.ExternalIp, err = d.GetExternalIp(); err != nil && !isUnknownMethod(err) {
+		return nil, err
+	}
+	var config struct {
+		DownloadLocation     string `json:"download_location"`
+		MaxConnectionsGlobal int    `json:"max_connections_global"`
+	}
+	keys := []string{"download_location", "max_connections_global"}
+	if err := d.call("core.get_config_values", []interface{}{keys}, &config); err != nil {
+		return nil, err
+	}
+	status.DownloadLocation = config.DownloadLocation
+	status.MaxNumConnections = config.MaxConnectionsGlobal
+	if status.FreeSpace, err = d.GetFreeSpace(config.DownloadLocation); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetFreeSpace gets the free disk space, in bytes, at path on the daemon host
+func (d *Deluge) GetFreeSpace(path string) (int64, error) {
+	var free float64
+	err := d.call("core.get_free_space", []interface{}{path}, &free)
+	return int64(free), err
+}
+
+// GetPathSize gets the size, in bytes, of path on the daemon host. It is -1
+// when path does not exist
+func (d *Deluge) GetPathSize(path string) (int64, error) {
+	var size float64
+	err := d.call("core.get_path_size", []interface{}{path}, &size)
+	return int64(size), err
+}
+
+// GetListenPort gets the port the daemon accepts incoming peer connections on
+func (d *Deluge) GetListenPort() (int, error) {
+	var port int
+	err := d.call("core.get_listen_port", nil, &port)
+	return port, err
+}
+
+// GetExternalIp gets the address the daemon is seen at by peers, which may be
+// empty until it is known. Deluge 1.3 does not provide it
+func (d *Deluge) GetExternalIp() (string, error) {
+	var ip string
+	err := d.call("core.get_external_ip", nil, &ip)
+	return ip, err
+}
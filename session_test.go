@@ -0,0 +1,76 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+)
+
+func TestGettingSessionStatus(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_session_status": `
+		{
+		  "id": 2,
+		  "result": {
+		    "payload_download_rate": 1048576.0,
+		    "payload_upload_rate": 262144.0,
+		    "download_rate": 1100000.0,
+		    "upload_rate": 280000.0,
+		    "dht_nodes": 312,
+		    "num_peers": 45,
+		    "has_incoming_connections": true
+		  },
+		  "error": null
+		}`,
+		"core.get_listen_port": `{"id": 3, "result": 6881, "error": null}`,
+		"core.get_external_ip": `{"id": 4, "result": "198.51.100.4", "error": null}`,
+		"core.get_config_values": `
+		{"id": 5, "result": {"download_location": "/mnt/downloads", "max_connections_global": 200}, "error": null}`,
+		"core.get_free_space": `{"id": 6, "result": 107374182400, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			status, err := client.GetSessionStatus()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 1048576.0, status.PayloadDownloadRate)
+			assert.Equal(t, 312, status.DhtNodes)
+			assert.Equal(t, true, status.HasIncomingConnections)
+			assert.Equal(t, 45, status.NumConnections)
+			assert.Equal(t, 200, status.MaxNumConnections)
+			assert.Equal(t, 6881, status.ListenPort)
+			assert.Equal(t, "198.51.100.4", status.ExternalIp)
+			assert.Equal(t, "/mnt/downloads", status.DownloadLocation)
+			assert.Equal(t, int64(107374182400), status.FreeSpace)
+			assertRequestParams(t, `[["download_location","max_connections_global"]]`, requests[4])
+			assertRequestParams(t, `["/mnt/downloads"]`, requests[5])
+			assert.Equal(t, 6, len(requests))
+		})
+}
+
+func TestGettingPathSize(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_path_size": `{"id": 2, "result": -1, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			size, err := client.GetPathSize("/mnt/missing")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, int64(-1), size)
+		})
+}
@@ -0,0 +1,142 @@
+package delugeclient
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// StatsKey is a metric recorded by the Stats plugin
+type StatsKey string
+
+const (
+	StatUploadRate          StatsKey = "upload_rate"
+	StatDownloadRate        StatsKey = "download_rate"
+	StatPayloadUploadRate   StatsKey = "payload_upload_rate"
+	StatPayloadDownloadRate StatsKey = "payload_download_rate"
+	StatNumConnections      StatsKey = "num_connections"
+	StatDhtNodes            StatsKey = "dht_nodes"
+	StatDhtCacheNodes       StatsKey = "dht_cache_nodes"
+	StatDhtTorrents         StatsKey = "dht_torrents"
+)
+
+// Sample is the value of a metric at a point in time. Rates are in bytes/s
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// StatsSeries are the samples of a set of metrics taken every Interval,
+// oldest first
+type StatsSeries struct {
+	Interval   time.Duration
+	LastUpdate time.Time
+	Series     map[StatsKey][]Sample
+}
+
+// StatsClient reads the history of the Stats plugin, which has to be enabled
+type StatsClient struct {
+	deluge *Deluge
+}
+
+// Stats returns the client of the Stats plugin
+func (d *Deluge) Stats() *StatsClient {
+	return &StatsClient{deluge: d}
+}
+
+// GetIntervals gets the sampling intervals the plugin keeps history for
+func (c *StatsClient) GetIntervals() ([]time.Duration, error) {
+	var rr []float64
+	if err := c.deluge.call("stats.get_intervals", nil, &rr); err != nil {
+		return nil, err
+	}
+	intervals := make([]time.Duration, 0, len(rr))
+	for _, seconds := range rr {
+		intervals = append(intervals, time.Duration(seconds*float64(time.Second)))
+	}
+	return intervals, nil
+}
+
+// GetStats gets the history of the given metrics sampled every interval,
+// which has to be one of GetIntervals
+func (c *StatsClient) GetStats(keys []StatsKey, interval time.Duration) (*StatsSeries, error) {
+	var rr map[string]json.RawMessage
+	seconds := int(interval / time.Second)
+	if err := c.deluge.call("stats.get_stats", []interface{}{keys, seconds}, &rr); err != nil {
+		return nil, err
+	}
+	if rr == nil {
+		return nil, fmt.Errorf("no stats kept every %s", interval)
+	}
+
+	stats := &StatsSeries{
+		Interval:   interval,
+		LastUpdate: decodeTime(rr["_last_update"]),
+		Series:     make(map[StatsKey][]Sample, len(keys)),
+	}
+	for _, key := range keys {
+		var values []float64
+		if raw, found := rr[string(key)]; found {
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return nil, fmt.Errorf("unable to parse %s stats. %s", key, err)
+			}
+		}
+		// the plugin sends the newest value first
+		samples := make([]Sample, len(values))
+		for i, value := range values {
+			samples[len(values)-1-i] = Sample{
+				Time:  stats.LastUpdate.Add(-time.Duration(i) * interval),
+				Value: value,
+			}
+		}
+		stats.Series[key] = samples
+	}
+	return stats, nil
+}
+
+// WriteCSV writes the samples as CSV, one row per point in time, with the
+// unix time in the first column followed by a column per metric in name order
+func (s *StatsSeries) WriteCSV(w io.Writer) error {
+	keys := make([]StatsKey, 0, len(s.Series))
+	for key := range s.Series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	rows := make(map[int64][]string)
+	var times []int64
+	for column, key := range keys {
+		for _, sample := range s.Series[key] {
+			t := sample.Time.Unix()
+			row, found := rows[t]
+			if !found {
+				row = make([]string, len(keys)+1)
+				row[0] = strconv.FormatInt(t, 10)
+				rows[t] = row
+				times = append(times, t)
+			}
+			row[column+1] = strconv.FormatFloat(sample.Value, 'f', -1, 64)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i] < times[j]
+	})
+
+	writer := csv.NewWriter(w)
+	header := []string{"time"}
+	for _, key := range keys {
+		header = append(header, string(key))
+	}
+	writer.Write(header)
+	for _, t := range times {
+		writer.Write(rows[t])
+	}
+	writer.Flush()
+	return writer.Error()
+}
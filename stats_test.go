@@ -0,0 +1,65 @@
+package delugeclient_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+	"time"
+)
+
+func TestGettingStats(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"stats.get_intervals": `{"id": 2, "result": [1, 5, 30, 300], "error": null}`,
+		"stats.get_stats": `
+		{
+		  "id": 3,
+		  "result": {
+		    "upload_rate": [300.0, 200.0, 100.0],
+		    "dht_nodes": [52, 51],
+		    "_last_update": 1700000010.0,
+		    "length": 150,
+		    "update_interval": 5
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			intervals, err := client.Stats().GetIntervals()
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, []time.Duration{time.Second, 5 * time.Second, 30 * time.Second, 5 * time.Minute}, intervals)
+
+			stats, err := client.Stats().GetStats(
+				[]delugeclient.StatsKey{delugeclient.StatUploadRate, delugeclient.StatDhtNodes}, 5*time.Second)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[["upload_rate","dht_nodes"],5]`, requests[2])
+			assert.Equal(t, []delugeclient.Sample{
+				{Time: time.Unix(1700000000, 0), Value: 100},
+				{Time: time.Unix(1700000005, 0), Value: 200},
+				{Time: time.Unix(1700000010, 0), Value: 300},
+			}, stats.Series[delugeclient.StatUploadRate])
+
+			var csv bytes.Buffer
+			if err := stats.WriteCSV(&csv); err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, "time,dht_nodes,upload_rate\n"+
+				"1700000000,,100\n"+
+				"1700000005,51,200\n"+
+				"1700000010,52,300\n", csv.String())
+		})
+}
@@ -0,0 +1,327 @@
+package delugeclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Field is a torrent status key
+type Field string
+
+const (
+	FieldName          Field = "name"
+	FieldHash          Field = "hash"
+	FieldState         Field = "state"
+	FieldMessage       Field = "message"
+	FieldSavePath      Field = "save_path"
+	FieldProgress      Field = "progress"
+	FieldRatio         Field = "ratio"
+	FieldTotalSize     Field = "total_size"
+	FieldTotalDone     Field = "total_done"
+	FieldTotalUploaded Field = "total_uploaded"
+	FieldEta           Field = "eta"
+	FieldDownloadRate  Field = "download_payload_rate"
+	FieldUploadRate    Field = "upload_payload_rate"
+	FieldNumSeeds      Field = "num_seeds"
+	FieldTotalSeeds    Field = "total_seeds"
+	FieldNumPeers      Field = "num_peers"
+	FieldTotalPeers    Field = "total_peers"
+	FieldTimeAdded     Field = "time_added"
+	FieldCompletedTime Field = "completed_time"
+	FieldActiveTime    Field = "active_time"
+	FieldSeedingTime   Field = "seeding_time"
+	FieldTracker       Field = "tracker"
+	FieldTrackerHost   Field = "tracker_host"
+	FieldTrackerStatus Field = "tracker_status"
+	FieldLabel         Field = "label"
+	FieldOwner         Field = "owner"
+	FieldShared        Field = "shared"
+	FieldIsFinished    Field = "is_finished"
+)
+
+// AllFields are the status keys TorrentStatus models
+var AllFields = []Field{
+	FieldName, FieldHash, FieldState, FieldMessage, FieldSavePath, FieldProgress, FieldRatio,
+	FieldTotalSize, FieldTotalDone, FieldTotalUploaded, FieldEta, FieldDownloadRate, FieldUploadRate,
+	FieldNumSeeds, FieldTotalSeeds, FieldNumPeers, FieldTotalPeers, FieldTimeAdded, FieldCompletedTime,
+	FieldActiveTime, FieldSeedingTime, FieldTracker, FieldTrackerHost, FieldTrackerStatus, FieldLabel,
+	FieldOwner, FieldShared, FieldIsFinished,
+}
+
+// TorrentStatus is the status of a torrent. Only the requested fields the
+// server knows about are filled in, which Has tells apart from zero values.
+// Sizes are in bytes, rates in bytes/s and progress goes from 0 to 100
+type TorrentStatus struct {
+	Id            string
+	Name          string
+	Hash          string
+	State         string
+	Message       string
+	SavePath      string
+	Progress      float64
+	Ratio         float64
+	TotalSize     int64
+	TotalDone     int64
+	TotalUploaded int64
+	Eta           time.Duration
+	DownloadRate  float64
+	UploadRate    float64
+	NumSeeds      int
+	TotalSeeds    int
+	NumPeers      int
+	TotalPeers    int
+	TimeAdded     time.Time
+	CompletedTime time.Time
+	ActiveTime    time.Duration
+	SeedingTime   time.Duration
+	Tracker       string
+	TrackerHost   string
+	TrackerStatus string
+	Label         string
+	Owner         string
+	Shared        bool
+	IsFinished    bool
+	present       map[Field]bool
+}
+
+// Has tells whether the server sent field
+func (s *TorrentStatus) Has(field Field) bool {
+	return s.present[field]
+}
+
+// UnmarshalJSON decodes a status leniently: numbers may come as integers,
+// floats or strings and flags as booleans or numbers, depending on the
+// Deluge version, and unknown keys are ignored
+func (s *TorrentStatus) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	s.present = make(map[Field]bool, len(raw))
+	for key, value := range raw {
+		field := Field(key)
+		s.present[field] = true
+		switch field {
+		case FieldName:
+			s.Name = decodeString(value)
+		case FieldHash:
+			s.Hash = decodeString(value)
+		case FieldState:
+			s.State = decodeString(value)
+		case FieldMessage:
+			s.Message = decodeString(value)
+		case FieldSavePath:
+			s.SavePath = decodeString(value)
+		case FieldProgress:
+			s.Progress = decodeNumber(value)
+		case FieldRatio:
+			s.Ratio = decodeNumber(value)
+		case FieldTotalSize:
+			s.TotalSize = int64(decodeNumber(value))
+		case FieldTotalDone:
+			s.TotalDone = int64(decodeNumber(value))
+		case FieldTotalUploaded:
+			s.TotalUploaded = int64(decodeNumber(value))
+		case FieldEta:
+			s.Eta = decodeSeconds(value)
+		case FieldDownloadRate:
+			s.DownloadRate = decodeNumber(value)
+		case FieldUploadRate:
+			s.UploadRate = decodeNumber(value)
+		case FieldNumSeeds:
+			s.NumSeeds = int(decodeNumber(value))
+		case FieldTotalSeeds:
+			s.TotalSeeds = int(decodeNumber(value))
+		case FieldNumPeers:
+			s.NumPeers = int(decodeNumber(value))
+		case FieldTotalPeers:
+			s.TotalPeers = int(decodeNumber(value))
+		case FieldTimeAdded:
+			s.TimeAdded = decodeTime(value)
+		case FieldCompletedTime:
+			s.CompletedTime = decodeTime(value)
+		case FieldActiveTime:
+			s.ActiveTime = decodeSeconds(value)
+		case FieldSeedingTime:
+			s.SeedingTime = decodeSeconds(value)
+		case FieldTracker:
+			s.Tracker = decodeString(value)
+		case FieldTrackerHost:
+			s.TrackerHost = decodeString(value)
+		case FieldTrackerStatus:
+			s.TrackerStatus = decodeString(value)
+		case FieldLabel:
+			s.Label = decodeString(value)
+		case FieldOwner:
+			s.Owner = decodeString(value)
+		case FieldShared:
+			s.Shared = isSet(value)
+		case FieldIsFinished:
+			s.IsFinished = isSet(value)
+		}
+	}
+	return nil
+}
+
+// Value returns the value of field, or nil when the status does not model it
+func (s *TorrentStatus) Value(field Field) interface{} {
+	switch field {
+	case FieldName:
+		return s.Name
+	case FieldHash:
+		return s.Hash
+	case FieldState:
+		return s.State
+	case FieldMessage:
+		return s.Message
+	case FieldSavePath:
+		return s.SavePath
+	case FieldProgress:
+		return s.Progress
+	case FieldRatio:
+		return s.Ratio
+	case FieldTotalSize:
+		return s.TotalSize
+	case FieldTotalDone:
+		return s.TotalDone
+	case FieldTotalUploaded:
+		return s.TotalUploaded
+	case FieldEta:
+		return s.Eta
+	case FieldDownloadRate:
+		return s.DownloadRate
+	case FieldUploadRate:
+		return s.UploadRate
+	case FieldNumSeeds:
+		return s.NumSeeds
+	case FieldTotalSeeds:
+		return s.TotalSeeds
+	case FieldNumPeers:
+		return s.NumPeers
+	case FieldTotalPeers:
+		return s.TotalPeers
+	case FieldTimeAdded:
+		return s.TimeAdded
+	case FieldCompletedTime:
+		return s.CompletedTime
+	case FieldActiveTime:
+		return s.ActiveTime
+	case FieldSeedingTime:
+		return s.SeedingTime
+	case FieldTracker:
+		return s.Tracker
+	case FieldTrackerHost:
+		return s.TrackerHost
+	case FieldTrackerStatus:
+		return s.TrackerStatus
+	case FieldLabel:
+		return s.Label
+	case FieldOwner:
+		return s.Owner
+	case FieldShared:
+		return s.Shared
+	case FieldIsFinished:
+		return s.IsFinished
+	}
+	return nil
+}
+
+// GetStatus gets the given status fields, or AllFields when none is given, of a
+// torrent given its hash id (torrentId)
+func (d *Deluge) GetStatus(torrentId string, fields ...Field) (*TorrentStatus, error) {
+	var status TorrentStatus
+	err := d.call("core.get_torrent_status", []interface{}{torrentId, statusKeys(fields)}, &status)
+	if err != nil {
+		return nil, err
+	}
+	if len(status.present) == 0 {
+		return nil, fmt.Errorf("torrent %s not found", torrentId)
+	}
+	status.Id = torrentId
+	return &status, nil
+}
+
+// GetStatuses gets the given status fields, or AllFields when none is given, of
+// the torrents with the given ids, ordered by name
+func (d *Deluge) GetStatuses(torrentIds []string, fields ...Field) ([]TorrentStatus, error) {
+	var rr map[string]TorrentStatus
+	if err := d.torrentsStatus(torrentIds, statusKeys(fields), &rr); err != nil {
+		return nil, err
+	}
+	return sortedStatuses(rr), nil
+}
+
+// torrentsStatus reads the given status keys of the torrents with the given ids
+// into result, which is keyed by torrent id
+func (d *Deluge) torrentsStatus(torrentIds []string, keys []string, result interface{}) error {
+	filter := map[string]interface{}{"id": torrentIds}
+	return d.call("core.get_torrents_status", []interface{}{filter, keys}, result)
+}
+
+// statusKeys are the keys of fields, or of AllFields when fields is empty, as an
+// empty key list makes the server send every key it knows
+func statusKeys(fields []Field) []string {
+	if len(fields) == 0 {
+		fields = AllFields
+	}
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, string(field))
+	}
+	return keys
+}
+
+func sortedStatuses(statuses map[string]TorrentStatus) []TorrentStatus {
+	sorted := make([]TorrentStatus, 0, len(statuses))
+	for id, status := range statuses {
+		status.Id = id
+		sorted = append(sorted, status)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+	return sorted
+}
+
+func decodeString(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	return ""
+}
+
+func decodeNumber(value json.RawMessage) float64 {
+	var n float64
+	if err := json.Unmarshal(value, &n); err == nil {
+		return n
+	}
+	if n, err := strconv.ParseFloat(decodeString(value), 64); err == nil {
+		return n
+	}
+	if isSet(value) {
+		return 1
+	}
+	return 0
+}
+
+func decodeSeconds(value json.RawMessage) time.Duration {
+	return time.Duration(decodeNumber(value) * float64(time.Second))
+}
+
+func decodeTime(value json.RawMessage) time.Time {
+	seconds := decodeNumber(value)
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*1e9))
+}
@@ -0,0 +1,126 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+	"time"
+)
+
+func TestGettingStatus(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrent_status": `
+		{
+		  "id": 2,
+		  "result": {
+		    "name": "Some.Linux.Distro",
+		    "state": "Downloading",
+		    "save_path": "/mnt/downloads",
+		    "total_size": 1019968543,
+		    "total_done": "509984271",
+		    "eta": 120,
+		    "download_payload_rate": 524288.0,
+		    "num_seeds": 12,
+		    "time_added": 1700000000.5,
+		    "completed_time": 0,
+		    "seeding_time": 0,
+		    "is_finished": 0,
+		    "unknown_key": [1, 2, 3]
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			status, err := client.GetStatus("asdfgh123456", delugeclient.FieldName, delugeclient.FieldState,
+				delugeclient.FieldTotalDone, delugeclient.FieldLabel)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `["asdfgh123456",["name","state","total_done","label"]]`, requests[1])
+			assert.Equal(t, "asdfgh123456", status.Id)
+			assert.Equal(t, "Some.Linux.Distro", status.Name)
+			assert.Equal(t, "Downloading", status.State)
+			assert.Equal(t, int64(1019968543), status.TotalSize)
+			assert.Equal(t, int64(509984271), status.TotalDone)
+			assert.Equal(t, 2*time.Minute, status.Eta)
+			assert.Equal(t, 524288.0, status.DownloadRate)
+			assert.Equal(t, 12, status.NumSeeds)
+			assert.Equal(t, time.Unix(1700000000, 500000000), status.TimeAdded)
+			assert.Equal(t, true, status.CompletedTime.IsZero())
+			assert.Equal(t, false, status.IsFinished)
+			assert.Equal(t, true, status.Has(delugeclient.FieldSeedingTime))
+			assert.Equal(t, false, status.Has(delugeclient.FieldLabel))
+			assert.Equal(t, "Downloading", status.Value(delugeclient.FieldState))
+			assert.Equal(t, int64(509984271), status.Value(delugeclient.FieldTotalDone))
+		})
+}
+
+func TestGettingStatuses(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": `
+		{
+		  "id": 2,
+		  "result": {
+		    "asdfgh123456": {"name": "Some.Video", "is_finished": true},
+		    "123456asdfgh": {"name": "Some.Linux.Distro", "is_finished": false}
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			statuses, err := client.GetStatuses([]string{"asdfgh123456", "123456asdfgh"},
+				delugeclient.FieldName, delugeclient.FieldIsFinished)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(statuses))
+			assert.Equal(t, "123456asdfgh", statuses[0].Id)
+			assert.Equal(t, "Some.Linux.Distro", statuses[0].Name)
+			assert.Equal(t, "asdfgh123456", statuses[1].Id)
+			assert.Equal(t, true, statuses[1].IsFinished)
+		})
+}
+
+func TestGettingStatusesOfAllFields(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": `
+		{
+		  "id": 2,
+		  "result": {"asdfgh123456": {"name": "Some.Video", "state": "Seeding"}},
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			statuses, err := client.GetStatuses([]string{"asdfgh123456"})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[{"id":["asdfgh123456"]},["name","hash","state","message","save_path",`+
+				`"progress","ratio","total_size","total_done","total_uploaded","eta","download_payload_rate",`+
+				`"upload_payload_rate","num_seeds","total_seeds","num_peers","total_peers","time_added",`+
+				`"completed_time","active_time","seeding_time","tracker","tracker_host","tracker_status","label",`+
+				`"owner","shared","is_finished"]]`, requests[1])
+			assert.Equal(t, 1, len(statuses))
+			assert.Equal(t, "Seeding", statuses[0].State)
+		})
+}
@@ -0,0 +1,93 @@
+package delugeclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// MoveResult is the outcome of moving the data of a single torrent
+type MoveResult struct {
+	Id       string
+	SavePath string
+	State    string
+	Done     bool
+	Err      error
+}
+
+// MoveStorage moves the data of the given torrents to dest and polls them every
+// interval until every move has completed or failed. When ctx is cancelled the
+// report gathered so far is returned along with the context error
+func (d *Deluge) MoveStorage(ctx context.Context, torrentIds []string, dest string, interval time.Duration) ([]MoveResult, error) {
+	if err := d.call("core.move_storage", []interface{}{torrentIds, dest}, nil); err != nil {
+		return nil, err
+	}
+
+	results := make([]MoveResult, len(torrentIds))
+	for i, id := range torrentIds {
+		results[i] = MoveResult{Id: id}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return results, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		pending, err := d.moveProgress(results, dest)
+		if err != nil {
+			return results, err
+		}
+		if pending == 0 {
+			return results, nil
+		}
+	}
+}
+
+func (d *Deluge) moveProgress(results []MoveResult, dest string) (int, error) {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		if !result.Done {
+			ids = append(ids, result.Id)
+		}
+	}
+	var rr map[string]struct {
+		SavePath string `json:"save_path"`
+		State    string `json:"state"`
+		Message  string `json:"message"`
+	}
+	if err := d.torrentsStatus(ids, []string{"save_path", "state", "message"}, &rr); err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for i := range results {
+		result := &results[i]
+		if result.Done {
+			continue
+		}
+		status, found := rr[result.Id]
+		switch {
+		case !found:
+			result.Done = true
+			result.Err = errors.New("torrent not found")
+		case status.State == "Error":
+			result.Done = true
+			result.Err = errors.New(status.Message)
+		case status.State != "Moving" && samePath(status.SavePath, dest):
+			result.Done = true
+		default:
+			pending++
+		}
+		if found {
+			result.SavePath = status.SavePath
+			result.State = status.State
+		}
+	}
+	return pending, nil
+}
+
+func samePath(a, b string) bool {
+	return strings.TrimRight(a, `/\`) == strings.TrimRight(b, `/\`)
+}
@@ -0,0 +1,67 @@
+package delugeclient_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+	"time"
+)
+
+func TestMovingStorage(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.move_storage": `{"id": 2, "result": null, "error": null}`,
+		"core.get_torrents_status": `
+		{
+		  "id": 3,
+		  "result": {
+		    "asdfgh123456": {"save_path": "/mnt/disk2/", "state": "Seeding", "message": "OK"},
+		    "123456asdfgh": {"save_path": "/mnt/disk1", "state": "Error", "message": "Permission denied"}
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			results, err := client.MoveStorage(context.Background(),
+				[]string{"asdfgh123456", "123456asdfgh", "unknown"}, "/mnt/disk2", time.Millisecond)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assertRequestParams(t, `[["asdfgh123456","123456asdfgh","unknown"],"/mnt/disk2"]`, requests[1])
+			assert.Equal(t, 3, len(results))
+			assert.Equal(t, true, results[0].Done)
+			assert.Equal(t, nil, results[0].Err)
+			assert.Equal(t, "/mnt/disk2/", results[0].SavePath)
+			assert.Equal(t, "Permission denied", results[1].Err.Error())
+			assert.Equal(t, "torrent not found", results[2].Err.Error())
+		})
+}
+
+func TestMovingStorageCancelled(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.move_storage": `{"id": 2, "result": null, "error": null}`,
+		"core.get_torrents_status": `
+		{"id": 3, "result": {"asdfgh123456": {"save_path": "/mnt/disk1", "state": "Moving"}}, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			results, err := client.MoveStorage(ctx, []string{"asdfgh123456"}, "/mnt/disk2", time.Millisecond)
+			assert.Equal(t, context.DeadlineExceeded, err)
+			assert.Equal(t, false, results[0].Done)
+			assert.Equal(t, "Moving", results[0].State)
+		})
+}
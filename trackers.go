@@ -0,0 +1,192 @@
+package delugeclient
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TorrentTracker is an announce url of a torrent and its tier
+type TorrentTracker struct {
+	Url  string `json:"url"`
+	Tier int    `json:"tier"`
+}
+
+// TrackerStatus is the announce state of a torrent with its current tracker
+type TrackerStatus struct {
+	Tracker      string
+	TrackerHost  string
+	Status       string
+	NextAnnounce time.Duration
+}
+
+// TrackerEdit turns the tracker list of a torrent into a new one
+type TrackerEdit func(trackers []TorrentTracker) []TorrentTracker
+
+// TrackerChange is the difference between the trackers of a torrent before
+// and after an edit, and the error applying it, if any
+type TrackerChange struct {
+	Id      string
+	Before  []TorrentTracker
+	After   []TorrentTracker
+	Added   []string
+	Removed []string
+	Err     error
+}
+
+// GetTrackers gets the trackers of a torrent given its hash id (torrentId)
+func (d *Deluge) GetTrackers(torrentId string) ([]TorrentTracker, error) {
+	var rr struct {
+		Trackers []TorrentTracker `json:"trackers"`
+	}
+	err := d.call("core.get_torrent_status", []interface{}{torrentId, []string{"trackers"}}, &rr)
+	if err != nil {
+		return nil, err
+	}
+	if rr.Trackers == nil {
+		return nil, fmt.Errorf("torrent %s not found", torrentId)
+	}
+	return rr.Trackers, nil
+}
+
+// SetTrackers replaces the trackers of a torrent given its hash id (torrentId)
+func (d *Deluge) SetTrackers(torrentId string, trackers []TorrentTracker) error {
+	if trackers == nil {
+		trackers = []TorrentTracker{}
+	}
+	return d.call("core.set_torrent_trackers", []interface{}{torrentId, trackers}, nil)
+}
+
+// GetTrackerStatus gets the announce state of the given torrents, keyed by torrent id
+func (d *Deluge) GetTrackerStatus(torrentIds []string) (map[string]TrackerStatus, error) {
+	var rr map[string]struct {
+		Tracker       string  `json:"tracker"`
+		TrackerHost   string  `json:"tracker_host"`
+		TrackerStatus string  `json:"tracker_status"`
+		NextAnnounce  float64 `json:"next_announce"`
+	}
+	keys := []string{"tracker", "tracker_host", "tracker_status", "next_announce"}
+	if err := d.torrentsStatus(torrentIds, keys, &rr); err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]TrackerStatus, len(rr))
+	for id, status := range rr {
+		statuses[id] = TrackerStatus{
+			Tracker:      status.Tracker,
+			TrackerHost:  status.TrackerHost,
+			Status:       status.TrackerStatus,
+			NextAnnounce: time.Duration(status.NextAnnounce) * time.Second,
+		}
+	}
+	return statuses, nil
+}
+
+// EditTrackers applies edit to the trackers of the given torrents and returns
+// the torrents whose trackers changed. With dryRun nothing is sent to the server
+func (d *Deluge) EditTrackers(torrentIds []string, edit TrackerEdit, dryRun bool) ([]TrackerChange, error) {
+	var rr map[string]struct {
+		Trackers []TorrentTracker `json:"trackers"`
+	}
+	if err := d.torrentsStatus(torrentIds, []string{"trackers"}, &rr); err != nil {
+		return nil, err
+	}
+
+	changes := make([]TrackerChange, 0)
+	for _, id := range torrentIds {
+		status, found := rr[id]
+		if !found {
+			continue
+		}
+		before := append([]TorrentTracker(nil), status.Trackers...)
+		after := edit(status.Trackers)
+		change := diffTrackers(id, before, after)
+		if change == nil {
+			continue
+		}
+		if !dryRun {
+			change.Err = d.SetTrackers(id, after)
+		}
+		changes = append(changes, *change)
+	}
+	return changes, nil
+}
+
+// AddTracker adds url at the given tier, unless the torrent already has it
+func AddTracker(url string, tier int) TrackerEdit {
+	return func(trackers []TorrentTracker) []TorrentTracker {
+		for _, tracker := range trackers {
+			if tracker.Url == url {
+				return trackers
+			}
+		}
+		return sortTrackers(append(trackers, TorrentTracker{Url: url, Tier: tier}))
+	}
+}
+
+// RemoveTracker removes url from the torrent
+func RemoveTracker(url string) TrackerEdit {
+	return func(trackers []TorrentTracker) []TorrentTracker {
+		kept := make([]TorrentTracker, 0, len(trackers))
+		for _, tracker := range trackers {
+			if tracker.Url != url {
+				kept = append(kept, tracker)
+			}
+		}
+		return kept
+	}
+}
+
+// ReplaceTracker rewrites the announce urls starting with oldPrefix to start
+// with newPrefix instead, keeping their tier
+func ReplaceTracker(oldPrefix, newPrefix string) TrackerEdit {
+	return func(trackers []TorrentTracker) []TorrentTracker {
+		rewritten := make([]TorrentTracker, 0, len(trackers))
+		for _, tracker := range trackers {
+			if strings.HasPrefix(tracker.Url, oldPrefix) {
+				tracker.Url = newPrefix + strings.TrimPrefix(tracker.Url, oldPrefix)
+			}
+			rewritten = append(rewritten, tracker)
+		}
+		return rewritten
+	}
+}
+
+func sortTrackers(trackers []TorrentTracker) []TorrentTracker {
+	sort.SliceStable(trackers, func(i, j int) bool {
+		return trackers[i].Tier < trackers[j].Tier
+	})
+	return trackers
+}
+
+func diffTrackers(torrentId string, before, after []TorrentTracker) *TrackerChange {
+	tiers := func(trackers []TorrentTracker) map[string]int {
+		m := make(map[string]int, len(trackers))
+		for _, tracker := range trackers {
+			m[tracker.Url] = tracker.Tier
+		}
+		return m
+	}
+	old, updated := tiers(before), tiers(after)
+
+	change := TrackerChange{Id: torrentId, Before: before, After: after}
+	moved := false
+	for _, tracker := range after {
+		tier, found := old[tracker.Url]
+		if !found {
+			change.Added = append(change.Added, tracker.Url)
+		} else if tier != tracker.Tier {
+			moved = true
+		}
+	}
+	for _, tracker := range before {
+		if _, found := updated[tracker.Url]; !found {
+			change.Removed = append(change.Removed, tracker.Url)
+		}
+	}
+	if len(change.Added) == 0 && len(change.Removed) == 0 && !moved {
+		return nil
+	}
+	return &change
+}
@@ -0,0 +1,127 @@
+package delugeclient_test
+
+import (
+	"fmt"
+	"github.com/adelolmo/delugeclient"
+	"github.com/bmizerany/assert"
+	"github.com/drewolson/testflight"
+	"testing"
+	"time"
+)
+
+const trackersStatusResponse = `
+{
+  "id": 2,
+  "result": {
+    "asdfgh123456": {"trackers": [
+      {"url": "https://old.tracker.org/announce/key1", "tier": 0},
+      {"url": "udp://open.tracker.net:1337", "tier": 1}
+    ]},
+    "123456asdfgh": {"trackers": [
+      {"url": "udp://open.tracker.net:1337", "tier": 0}
+    ]}
+  },
+  "error": null
+}`
+
+func TestGettingTrackers(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrent_status": `
+		{"id": 2, "result": {"trackers": [{"url": "udp://open.tracker.net:1337", "tier": 0}]}, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			trackers, err := client.GetTrackers("asdfgh123456")
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, []delugeclient.TorrentTracker{{Url: "udp://open.tracker.net:1337", Tier: 0}}, trackers)
+		})
+}
+
+func TestGettingTrackerStatus(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": `
+		{
+		  "id": 2,
+		  "result": {
+		    "asdfgh123456": {
+		      "tracker": "udp://open.tracker.net:1337",
+		      "tracker_host": "tracker.net",
+		      "tracker_status": "Announce OK",
+		      "next_announce": 1740
+		    }
+		  },
+		  "error": null
+		}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			statuses, err := client.GetTrackerStatus([]string{"asdfgh123456"})
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, "tracker.net", statuses["asdfgh123456"].TrackerHost)
+			assert.Equal(t, "Announce OK", statuses["asdfgh123456"].Status)
+			assert.Equal(t, 29*time.Minute, statuses["asdfgh123456"].NextAnnounce)
+		})
+}
+
+func TestEditingTrackersDryRun(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status": trackersStatusResponse,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			changes, err := client.EditTrackers([]string{"asdfgh123456", "123456asdfgh"},
+				delugeclient.ReplaceTracker("https://old.tracker.org/", "https://new.tracker.org/"), true)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(requests))
+			assert.Equal(t, 1, len(changes))
+			assert.Equal(t, "asdfgh123456", changes[0].Id)
+			assert.Equal(t, []string{"https://new.tracker.org/announce/key1"}, changes[0].Added)
+			assert.Equal(t, []string{"https://old.tracker.org/announce/key1"}, changes[0].Removed)
+		})
+}
+
+func TestEditingTrackers(t *testing.T) {
+	var requests []string
+	testflight.WithServer(MethodHandler(map[string]string{
+		"core.get_torrents_status":  trackersStatusResponse,
+		"core.set_torrent_trackers": `{"id": 3, "result": null, "error": null}`,
+	}, &requests),
+		func(r *testflight.Requester) {
+			client := delugeclient.NewDeluge("http://"+r.Url(""), "pass")
+			if err := client.Connect(); err != nil {
+				t.Fail()
+			}
+			changes, err := client.EditTrackers([]string{"asdfgh123456", "123456asdfgh"},
+				delugeclient.RemoveTracker("udp://open.tracker.net:1337"), false)
+			if err != nil {
+				fmt.Println(err)
+				t.Fail()
+			}
+			assert.Equal(t, 2, len(changes))
+			assert.Equal(t, nil, changes[0].Err)
+			assertRequestParams(t,
+				`["asdfgh123456",[{"url":"https://old.tracker.org/announce/key1","tier":0}]]`, requests[2])
+			assertRequestParams(t, `["123456asdfgh",[]]`, requests[3])
+		})
+}